@@ -0,0 +1,135 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// bagKey identifies an amount in a Bag
+type bagKey struct {
+	code    string
+	fushare uint
+}
+
+// Bag accumulates amounts of multiple currencies, keeping a total per currency code & fractional
+// share. Totals which become 0 are removed. The zero value is an empty bag ready to use. A Bag is
+// not safe for concurrent use.
+type Bag struct {
+	totals map[bagKey]Currency
+}
+
+// NewBag returns a new bag with the given amounts added.
+func NewBag(cs ...Currency) (*Bag, error) {
+	b := &Bag{}
+	for _, c := range cs {
+		err := b.Add(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// Add adds c to the total of its currency.
+func (b *Bag) Add(c Currency) error {
+	return b.update(c, (*Currency).Add)
+}
+
+// Subtract subtracts c from the total of its currency.
+func (b *Bag) Subtract(c Currency) error {
+	return b.update(c, (*Currency).Subtract)
+}
+
+// Merge adds all the totals of other to b.
+func (b *Bag) Merge(other *Bag) error {
+	for _, c := range other.Totals() {
+		err := b.Add(c)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Negate negates all the totals in the bag.
+func (b *Bag) Negate() {
+	for key, c := range b.totals {
+		c.UpdateWithFractional(-c.FractionalTotal())
+		b.totals[key] = c
+	}
+}
+
+// Len returns the number of currencies in the bag.
+func (b *Bag) Len() int {
+	return len(b.totals)
+}
+
+// Totals returns the total of every currency in the bag, sorted by code & fractional share.
+func (b *Bag) Totals() []Currency {
+	totals := make([]Currency, 0, len(b.totals))
+	for _, c := range b.totals {
+		totals = append(totals, c)
+	}
+
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Code != totals[j].Code {
+			return totals[i].Code < totals[j].Code
+		}
+		return totals[i].FUShare < totals[j].FUShare
+	})
+
+	return totals
+}
+
+// Reduce converts all the totals in the bag to the currency `to` using the converter, and
+// returns their sum. The sum is computed with the exact rates, and rounded once to the
+// fractional unit of `to` using the converter's rounding mode.
+func (b *Bag) Reduce(ctx context.Context, cv *Converter, to string, at time.Time) (*Currency, error) {
+	m, err := Lookup(to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", err, to)
+	}
+
+	sum := new(big.Rat)
+	for _, c := range b.Totals() {
+		er, err := cv.Rate(ctx, c.Code, to, at)
+		if err != nil {
+			return nil, err
+		}
+
+		sum.Add(sum, new(big.Rat).Mul(c.rat(), er.Rate))
+	}
+
+	return fromRat(m, sum, cv.Rounding)
+}
+
+// update applies op to the total of c's currency, removing the total if it becomes 0
+func (b *Bag) update(c Currency, op func(*Currency, Currency) error) error {
+	if c.FUShare == 0 {
+		return ErrInvalidFUS
+	}
+
+	key := bagKey{code: c.Code, fushare: c.FUShare}
+	total := b.totals[key]
+	err := op(&total, c)
+	if err != nil {
+		return err
+	}
+
+	if total.FractionalTotal() == 0 {
+		delete(b.totals, key)
+		return nil
+	}
+
+	if b.totals == nil {
+		b.totals = make(map[bagKey]Currency)
+	}
+	b.totals[key] = total
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package currency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bagTotals(b *Bag) []string {
+	totals := []string{}
+	for _, c := range b.Totals() {
+		totals = append(totals, c.Code+" "+c.StringWithoutSymbols())
+	}
+
+	return totals
+}
+
+func TestBag(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	usd, err := New(10, 50, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+	inr, err := New(100, 25, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+	jpy, err := New(500, 0, "JPY", "¥", "", 1)
+	requirer.NoError(err)
+
+	b := &Bag{}
+	requirer.NoError(b.Add(*usd))
+	requirer.NoError(b.Add(*inr))
+	requirer.NoError(b.Add(*usd))
+	requirer.NoError(b.Add(*jpy))
+	asserter.Equal([]string{"INR 100.25", "JPY 500.0", "USD 21.00"}, bagTotals(b))
+	asserter.Equal(3, b.Len())
+
+	requirer.NoError(b.Subtract(*jpy))
+	asserter.Equal([]string{"INR 100.25", "USD 21.00"}, bagTotals(b))
+
+	// same code with a different fractional share is kept separately
+	mills, err := NewFractional(1005, "USD", "$", "mill", 1000)
+	requirer.NoError(err)
+	requirer.NoError(b.Subtract(*mills))
+	asserter.Equal([]string{"INR 100.25", "USD 21.00", "USD -1.005"}, bagTotals(b))
+
+	b.Negate()
+	asserter.Equal([]string{"INR -100.25", "USD -21.00", "USD 1.005"}, bagTotals(b))
+
+	other, err := NewBag(*inr, *jpy)
+	requirer.NoError(err)
+	requirer.NoError(b.Merge(other))
+	asserter.Equal([]string{"JPY 500.0", "USD -21.00", "USD 1.005"}, bagTotals(b))
+
+	// the symbol & names are retained
+	totals := b.Totals()
+	asserter.Equal("¥", totals[0].Symbol)
+	asserter.Equal("mill", totals[2].FUName)
+
+	asserter.ErrorIs(b.Add(Currency{}), ErrInvalidFUS)
+	_, err = NewBag(Currency{Code: "USD"})
+	asserter.ErrorIs(err, ErrInvalidFUS)
+
+	empty := Bag{}
+	empty.Negate()
+	asserter.Empty(empty.Totals())
+}
+
+func TestBagReduce(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	ctx := context.Background()
+	cv := &Converter{
+		Rates: newTestRateTable(t,
+			[3]string{"USD", "INR", "83.1275"},
+			[3]string{"EUR", "USD", "1.0811"},
+		),
+		Pivots:   []string{"USD"},
+		Rounding: RoundHalfEven,
+	}
+
+	usd, err := New(10, 0, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+	eur, err := New(0, 1, "EUR", "€", "cent", 100)
+	requirer.NoError(err)
+	inr, err := New(100, 0, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+
+	b, err := NewBag(*usd, *inr, *eur, *eur)
+	requirer.NoError(err)
+
+	// 10 × 83.1275 + 100 + 0.02 × 1.0811 × 83.1275 = 931.27500... + 1.79738...
+	total, err := b.Reduce(ctx, cv, "INR", time.Now())
+	requirer.NoError(err)
+	asserter.Equal("INR", total.Code)
+	asserter.Equal("933.07", total.StringWithoutSymbols())
+
+	total, err = (&Bag{}).Reduce(ctx, cv, "INR", time.Now())
+	requirer.NoError(err)
+	asserter.Equal(0, total.FractionalTotal())
+
+	jpy, err := New(500, 0, "JPY", "¥", "", 1)
+	requirer.NoError(err)
+	requirer.NoError(b.Add(*jpy))
+	_, err = b.Reduce(ctx, cv, "INR", time.Now())
+	asserter.ErrorIs(err, ErrRateNotFound)
+
+	_, err = b.Reduce(ctx, cv, "XYZ", time.Now())
+	asserter.ErrorIs(err, ErrUnknownCurrency)
+}
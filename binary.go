@@ -0,0 +1,113 @@
+package currency
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidEncoding is the error returned when unmarshalling a malformed binary encoding
+	ErrInvalidEncoding = errors.New("invalid binary encoding")
+	// ErrUnsupportedVersion is the error returned when unmarshalling a binary encoding of an unknown version
+	ErrUnsupportedVersion = errors.New("unsupported binary encoding version")
+)
+
+// binaryVersion is the current version of the binary encoding.
+/*
+   Version 1 layout:
+   - 1 byte version
+   - uvarint ISO 4217 numeric code, 0 for the zero value of Currency
+   - uvarint FUShare
+   - varint fractional total
+*/
+const binaryVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler, it is also used by encoding/gob. The
+// currency must be in the registry with an ISO 4217 numeric code.
+func (c Currency) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 1, 1+3*binary.MaxVarintLen64)
+	buf[0] = binaryVersion
+
+	if c.isBlank() {
+		buf = appendUvarint(buf, 0)
+		buf = appendUvarint(buf, 0)
+		return appendVarint(buf, 0), nil
+	}
+
+	if c.FUShare == 0 {
+		return nil, ErrInvalidFUS
+	}
+
+	m, err := Lookup(c.Code)
+	if err != nil || m.Numeric == 0 {
+		return nil, fmt.Errorf("%w: %q has no numeric code", ErrUnknownCurrency, c.Code)
+	}
+
+	buf = appendUvarint(buf, uint64(m.Numeric))
+	buf = appendUvarint(buf, uint64(c.FUShare))
+	return appendVarint(buf, int64(c.FractionalTotal())), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The code, symbol & fractional unit
+// name are taken from the registry, based on the numeric code.
+func (c *Currency) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return ErrInvalidEncoding
+	}
+
+	if data[0] != binaryVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, data[0])
+	}
+	data = data[1:]
+
+	numeric, n := binary.Uvarint(data)
+	if n <= 0 || numeric > 0xffff {
+		return ErrInvalidEncoding
+	}
+	data = data[n:]
+
+	fushare, n := binary.Uvarint(data)
+	if n <= 0 || uint64(uint(fushare)) != fushare {
+		return ErrInvalidEncoding
+	}
+	data = data[n:]
+
+	ftotal, n := binary.Varint(data)
+	if n <= 0 || n != len(data) || int64(int(ftotal)) != ftotal {
+		return ErrInvalidEncoding
+	}
+
+	if numeric == 0 {
+		if fushare != 0 || ftotal != 0 {
+			return ErrInvalidEncoding
+		}
+
+		*c = Currency{PrefixSymbol: c.PrefixSymbol, SuffixSymbol: c.SuffixSymbol}
+		return nil
+	}
+
+	if fushare == 0 {
+		return ErrInvalidEncoding
+	}
+
+	m, err := LookupNumeric(uint16(numeric))
+	if err != nil {
+		return fmt.Errorf("%w: numeric code %d", err, numeric)
+	}
+	m.FUShare = uint(fushare)
+
+	return c.setFractional(m, int(ftotal))
+}
+
+func appendUvarint(buf []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	return append(buf, tmp[:n]...)
+}
+
+func appendVarint(buf []byte, v int64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(tmp[:], v)
+	return append(buf, tmp[:n]...)
+}
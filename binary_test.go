@@ -0,0 +1,137 @@
+package currency
+
+import (
+	"bytes"
+	"encoding/gob"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestMarshalBinaryGolden(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	usd, err := New(12, 50, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	inr, err := NewFractional(-105099, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+
+	kwd, err := New(123456789, 999, "KWD", "د.ك", "fils", 1000)
+	requirer.NoError(err)
+
+	jpy, err := New(1200, 0, "JPY", "¥", "", 1)
+	requirer.NoError(err)
+
+	list := []struct {
+		Name     string
+		Currency *Currency
+	}{
+		{Name: "usd", Currency: usd},
+		{Name: "inr_negative", Currency: inr},
+		{Name: "kwd_large", Currency: kwd},
+		{Name: "jpy", Currency: jpy},
+		{Name: "zero_value", Currency: &Currency{}},
+	}
+
+	for _, l := range list {
+		golden := filepath.Join("testdata", "binary_v1_"+l.Name+".golden")
+
+		got, err := l.Currency.MarshalBinary()
+		requirer.NoError(err, l.Name)
+
+		if *updateGolden {
+			requirer.NoError(os.WriteFile(golden, got, 0644))
+		}
+
+		expected, err := os.ReadFile(golden)
+		requirer.NoError(err, l.Name)
+		asserter.Equal(expected, got, l.Name)
+
+		decoded := Currency{}
+		requirer.NoError(decoded.UnmarshalBinary(expected), l.Name)
+		asserter.Equal(l.Currency.Code, decoded.Code, l.Name)
+		asserter.Equal(l.Currency.FUShare, decoded.FUShare, l.Name)
+		asserter.Equal(l.Currency.FractionalTotal(), decoded.FractionalTotal(), l.Name)
+		asserter.Equal(l.Currency.String(), decoded.String(), l.Name)
+	}
+}
+
+func TestMarshalBinaryErrors(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	_, err := Currency{Code: "USD", Main: 1}.MarshalBinary()
+	asserter.ErrorIs(err, ErrInvalidFUS)
+
+	custom, err := New(1, 0, "XBT", "₿", "satoshi", 100000000)
+	requirer.NoError(err)
+	_, err = custom.MarshalBinary()
+	asserter.ErrorIs(err, ErrUnknownCurrency)
+
+	list := []struct {
+		Name  string
+		Input []byte
+		Err   error
+	}{
+		{Name: "empty", Input: []byte{}, Err: ErrInvalidEncoding},
+		{Name: "version", Input: []byte{2, 0xc8, 0x06, 0x64, 0x02}, Err: ErrUnsupportedVersion},
+		{Name: "truncated", Input: []byte{1, 0xc8, 0x06, 0x64}, Err: ErrInvalidEncoding},
+		{Name: "trailing", Input: []byte{1, 0xc8, 0x06, 0x64, 0x02, 0x00}, Err: ErrInvalidEncoding},
+		{Name: "zero fushare", Input: []byte{1, 0xc8, 0x06, 0x00, 0x02}, Err: ErrInvalidEncoding},
+		{Name: "zero value with amount", Input: []byte{1, 0x00, 0x00, 0x02}, Err: ErrInvalidEncoding},
+		{Name: "unknown numeric", Input: []byte{1, 0x01, 0x64, 0x02}, Err: ErrUnknownCurrency},
+	}
+
+	for _, l := range list {
+		cur := Currency{}
+		asserter.ErrorIs(cur.UnmarshalBinary(l.Input), l.Err, l.Name)
+	}
+}
+
+func TestGob(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	usd, err := New(12, 50, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	type payment struct {
+		ID     int
+		Amount Currency
+		Refund *Currency
+	}
+
+	buf := bytes.NewBuffer(nil)
+	requirer.NoError(gob.NewEncoder(buf).Encode(payment{ID: 1, Amount: *usd, Refund: usd}))
+
+	decoded := payment{}
+	requirer.NoError(gob.NewDecoder(buf).Decode(&decoded))
+	asserter.Equal(1, decoded.ID)
+	asserter.Equal("12.50", decoded.Amount.StringWithoutSymbols())
+	asserter.Equal("USD", decoded.Amount.Code)
+	requirer.NotNil(decoded.Refund)
+	asserter.Equal(1250, decoded.Refund.FractionalTotal())
+}
+
+func BenchmarkMarshalBinary(t *testing.B) {
+	cur, _ := New(10, 5, "INR", "₹", "paise", 100)
+	for i := 0; i < t.N; i++ {
+		_, _ = cur.MarshalBinary()
+	}
+}
+
+func BenchmarkUnmarshalBinary(t *testing.B) {
+	cur, _ := New(10, 5, "INR", "₹", "paise", 100)
+	data, _ := cur.MarshalBinary()
+	for i := 0; i < t.N; i++ {
+		_ = cur.UnmarshalBinary(data)
+	}
+}
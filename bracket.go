@@ -0,0 +1,291 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrInvalidBrackets is the error returned when the brackets or surcharges of a progressive tax
+// are invalid, e.g. out of order
+var ErrInvalidBrackets = errors.New("invalid tax brackets provided")
+
+// TaxBracket is a bracket (slab) of a progressive tax, from the UpTo of the preceding bracket
+// (or 0 for the first bracket) up to its own UpTo.
+type TaxBracket struct {
+	// UpTo is the inclusive upper bound of the bracket, nil for the last bracket
+	UpTo *Currency
+	// Rate is the rate of tax on the part of the income within the bracket
+	Rate Rate
+}
+
+// Surcharge is a surcharge on the tax, levied when the income is more than Above.
+type Surcharge struct {
+	Above Currency
+	// Rate is the rate of surcharge on the tax, e.g. 10%
+	Rate Rate
+}
+
+// ProgressiveTax computes income tax from brackets, e.g. 0-3L at 0%, 3-7L at 5% and so on,
+// along with surcharges & cess.
+type ProgressiveTax struct {
+	// Brackets are in increasing order of UpTo, where the last bracket has no upper bound
+	Brackets []TaxBracket
+	// Surcharges are in increasing order of Above. Only the surcharge with the highest threshold
+	// crossed by the income is levied.
+	Surcharges []Surcharge
+	// Cess is the rate of cess levied on the tax plus surcharge, e.g. 4%. Zero if not applicable.
+	Cess Rate
+	// Rounding is the rounding mode used for all the amounts
+	Rounding RoundingMode
+}
+
+// BracketTax is the tax computed in a bracket.
+type BracketTax struct {
+	TaxBracket
+	// From is the lower bound of the bracket
+	From Currency
+	// Taxable is the part of the income within the bracket
+	Taxable Currency
+	// Tax is the tax on Taxable
+	Tax Currency
+}
+
+// ProgressiveTaxResult is the income tax computed by ProgressiveTax.
+type ProgressiveTaxResult struct {
+	Income Currency
+	// Brackets is the tax in every bracket, all of which add up to Tax
+	Brackets []BracketTax
+	// Tax is the sum of the exact tax in all the brackets, rounded once
+	Tax Currency
+	// Surcharge is the surcharge on Tax, before marginal relief
+	Surcharge Currency
+	// MarginalRelief is the reduction in surcharge so that the tax plus surcharge above a
+	// surcharge threshold doesn't increase by more than the income above the threshold
+	MarginalRelief Currency
+	// Cess is the cess on Tax + Surcharge - MarginalRelief
+	Cess Currency
+	// Total is Tax + Surcharge - MarginalRelief + Cess
+	Total Currency
+}
+
+// Compute computes the tax on the income, which must not be negative. The tax of every bracket
+// is computed exactly, and their sum is rounded once & allocated back to the brackets in
+// proportion to their exact tax.
+func (pt ProgressiveTax) Compute(income *Currency) (*ProgressiveTaxResult, error) {
+	if income.FUShare == 0 {
+		return nil, ErrInvalidFUS
+	}
+
+	if income.FractionalTotal() < 0 {
+		return nil, fmt.Errorf("%w: negative income %s", ErrInvalidBrackets, income.String())
+	}
+
+	err := pt.validate(income)
+	if err != nil {
+		return nil, err
+	}
+
+	ft := income.FractionalTotal()
+	exact, taxable := pt.bracketTaxes(ft)
+	tax, err := pt.tax(ft)
+	if err != nil {
+		return nil, err
+	}
+
+	ptr := &ProgressiveTaxResult{Income: *income, Brackets: make([]BracketTax, 0, len(pt.Brackets))}
+	ptr.Tax, ptr.Surcharge, ptr.MarginalRelief, ptr.Cess, ptr.Total = *income, *income, *income, *income, *income
+	ptr.Tax.UpdateWithFractional(tax)
+
+	shares := make([]Currency, len(exact))
+	if tax != 0 {
+		shares, err = ptr.Tax.allocate(ratWeights(exact))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for i := range shares {
+			shares[i] = ptr.Tax
+		}
+	}
+
+	from := 0
+	for i, b := range pt.Brackets {
+		bt := BracketTax{TaxBracket: b, From: *income, Taxable: *income, Tax: shares[i]}
+		bt.From.UpdateWithFractional(from)
+		bt.Taxable.UpdateWithFractional(taxable[i])
+		ptr.Brackets = append(ptr.Brackets, bt)
+
+		if b.UpTo != nil {
+			from = b.UpTo.FractionalTotal()
+		}
+	}
+
+	surcharge, relief, err := pt.surcharge(ft, tax)
+	if err != nil {
+		return nil, err
+	}
+
+	cess, err := roundFractional(
+		new(big.Rat).Mul(new(big.Rat).SetInt64(int64(tax+surcharge-relief)), pt.Cess.Rat()),
+		pt.Rounding,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ptr.Surcharge.UpdateWithFractional(surcharge)
+	ptr.MarginalRelief.UpdateWithFractional(relief)
+	ptr.Cess.UpdateWithFractional(cess)
+	ptr.Total.UpdateWithFractional(tax + surcharge - relief + cess)
+
+	return ptr, nil
+}
+
+// bracketTaxes returns the exact tax & the taxable income of every bracket, for the income in
+// fractional units
+func (pt ProgressiveTax) bracketTaxes(ft int) ([]*big.Rat, []int) {
+	exact := make([]*big.Rat, 0, len(pt.Brackets))
+	taxable := make([]int, 0, len(pt.Brackets))
+
+	from := 0
+	for _, b := range pt.Brackets {
+		upTo := ft
+		if b.UpTo != nil && b.UpTo.FractionalTotal() < ft {
+			upTo = b.UpTo.FractionalTotal()
+		}
+
+		t := 0
+		if upTo > from {
+			t = upTo - from
+		}
+
+		taxable = append(taxable, t)
+		exact = append(exact, new(big.Rat).Mul(new(big.Rat).SetInt64(int64(t)), b.Rate.Rat()))
+
+		if b.UpTo != nil {
+			from = b.UpTo.FractionalTotal()
+		}
+	}
+
+	return exact, taxable
+}
+
+// tax returns the rounded tax of all the brackets, for the income in fractional units
+func (pt ProgressiveTax) tax(ft int) (int, error) {
+	exact, _ := pt.bracketTaxes(ft)
+	sum := new(big.Rat)
+	for _, t := range exact {
+		sum.Add(sum, t)
+	}
+
+	return roundFractional(sum, pt.Rounding)
+}
+
+// surcharge returns the surcharge on the tax & the marginal relief, for the income in
+// fractional units. The tax plus surcharge is limited to the tax plus surcharge at the
+// threshold crossed, plus the income above the threshold.
+func (pt ProgressiveTax) surcharge(ft int, tax int) (int, int, error) {
+	idx := -1
+	for i, s := range pt.Surcharges {
+		if ft > s.Above.FractionalTotal() {
+			idx = i
+		}
+	}
+
+	if idx < 0 {
+		return 0, 0, nil
+	}
+
+	surcharge, err := roundFractional(
+		new(big.Rat).Mul(new(big.Rat).SetInt64(int64(tax)), pt.Surcharges[idx].Rate.Rat()),
+		pt.Rounding,
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	threshold := pt.Surcharges[idx].Above.FractionalTotal()
+	thresholdTax, err := pt.tax(threshold)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	thresholdSurcharge := 0
+	if idx > 0 {
+		thresholdSurcharge, err = roundFractional(
+			new(big.Rat).Mul(new(big.Rat).SetInt64(int64(thresholdTax)), pt.Surcharges[idx-1].Rate.Rat()),
+			pt.Rounding,
+		)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	limit := thresholdTax + thresholdSurcharge + ft - threshold
+	relief := tax + surcharge - limit
+	if relief < 0 {
+		relief = 0
+	}
+
+	if relief > surcharge {
+		relief = surcharge
+	}
+
+	return surcharge, relief, nil
+}
+
+// validate checks that all the amounts match the currency of income, that the brackets &
+// surcharges are in order, and that the rates are not negative
+func (pt ProgressiveTax) validate(income *Currency) error {
+	if len(pt.Brackets) == 0 {
+		return fmt.Errorf("%w: no brackets", ErrInvalidBrackets)
+	}
+
+	if pt.Cess.Rat().Sign() < 0 {
+		return fmt.Errorf("%w: negative cess %s", ErrInvalidBrackets, pt.Cess)
+	}
+
+	for i, b := range pt.Brackets {
+		if b.Rate.Rat().Sign() < 0 {
+			return fmt.Errorf("%w: bracket %d has a negative rate", ErrInvalidBrackets, i+1)
+		}
+
+		if b.UpTo == nil {
+			if i != len(pt.Brackets)-1 {
+				return fmt.Errorf("%w: only the last bracket can be without an upper bound", ErrInvalidBrackets)
+			}
+			continue
+		}
+
+		if i == len(pt.Brackets)-1 {
+			return fmt.Errorf("%w: the last bracket must be without an upper bound", ErrInvalidBrackets)
+		}
+
+		err := income.match("ProgressiveTax", b.UpTo)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 && b.UpTo.FractionalTotal() <= pt.Brackets[i-1].UpTo.FractionalTotal() {
+			return fmt.Errorf("%w: bracket %d is not above bracket %d", ErrInvalidBrackets, i+1, i)
+		}
+	}
+
+	for i, s := range pt.Surcharges {
+		if s.Rate.Rat().Sign() < 0 {
+			return fmt.Errorf("%w: surcharge %d has a negative rate", ErrInvalidBrackets, i+1)
+		}
+
+		err := income.match("ProgressiveTax", &s.Above)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 && s.Above.FractionalTotal() <= pt.Surcharges[i-1].Above.FractionalTotal() {
+			return fmt.Errorf("%w: surcharge %d is not above surcharge %d", ErrInvalidBrackets, i+1, i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func inr(t *testing.T, value string) *Currency {
+	c, err := ParseDecimal(value, "INR", "₹", "paise", 100)
+	require.NoError(t, err)
+
+	return c
+}
+
+func indianIncomeTax(t *testing.T) ProgressiveTax {
+	return ProgressiveTax{
+		Brackets: []TaxBracket{
+			{UpTo: inr(t, "300000"), Rate: MustParseRate("0%")},
+			{UpTo: inr(t, "700000"), Rate: MustParseRate("5%")},
+			{UpTo: inr(t, "1000000"), Rate: MustParseRate("10%")},
+			{UpTo: inr(t, "1200000"), Rate: MustParseRate("15%")},
+			{UpTo: inr(t, "1500000"), Rate: MustParseRate("20%")},
+			{Rate: MustParseRate("30%")},
+		},
+		Surcharges: []Surcharge{
+			{Above: *inr(t, "5000000"), Rate: MustParseRate("10%")},
+			{Above: *inr(t, "10000000"), Rate: MustParseRate("15%")},
+			{Above: *inr(t, "20000000"), Rate: MustParseRate("25%")},
+		},
+		Cess: MustParseRate("4%"),
+	}
+}
+
+func TestProgressiveTax(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	pt := indianIncomeTax(t)
+
+	list := []struct {
+		Income         string
+		Tax            string
+		Surcharge      string
+		MarginalRelief string
+		Cess           string
+		Total          string
+	}{
+		{Income: "250000", Tax: "0.00", Surcharge: "0.00", MarginalRelief: "0.00", Cess: "0.00", Total: "0.00"},
+		{Income: "1200000", Tax: "80000.00", Surcharge: "0.00", MarginalRelief: "0.00", Cess: "3200.00", Total: "83200.00"},
+		{Income: "5000000", Tax: "1190000.00", Surcharge: "0.00", MarginalRelief: "0.00", Cess: "47600.00", Total: "1237600.00"},
+		// tax + surcharge is limited to 11,90,000 + 1,00,000
+		{Income: "5100000", Tax: "1220000.00", Surcharge: "122000.00", MarginalRelief: "52000.00", Cess: "51600.00", Total: "1341600.00"},
+		{Income: "6000000", Tax: "1490000.00", Surcharge: "149000.00", MarginalRelief: "0.00", Cess: "65560.00", Total: "1704560.00"},
+		// tax + surcharge is limited to 26,90,000 + 2,69,000 (10% surcharge) + 10,000
+		{Income: "10010000", Tax: "2693000.00", Surcharge: "403950.00", MarginalRelief: "127950.00", Cess: "118760.00", Total: "3087760.00"},
+	}
+
+	for _, l := range list {
+		ptr, err := pt.Compute(inr(t, l.Income))
+		requirer.NoError(err, l.Income)
+		asserter.Equal(l.Tax, ptr.Tax.StringWithoutSymbols(), l.Income)
+		asserter.Equal(l.Surcharge, ptr.Surcharge.StringWithoutSymbols(), l.Income)
+		asserter.Equal(l.MarginalRelief, ptr.MarginalRelief.StringWithoutSymbols(), l.Income)
+		asserter.Equal(l.Cess, ptr.Cess.StringWithoutSymbols(), l.Income)
+		asserter.Equal(l.Total, ptr.Total.StringWithoutSymbols(), l.Income)
+		asserter.Equal("INR", ptr.Total.Code)
+
+		sum := 0
+		for _, b := range ptr.Brackets {
+			sum += b.Tax.FractionalTotal()
+		}
+		asserter.Equal(ptr.Tax.FractionalTotal(), sum, l.Income)
+	}
+}
+
+func TestProgressiveTaxBrackets(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	pt := indianIncomeTax(t)
+	ptr, err := pt.Compute(inr(t, "1100000"))
+	requirer.NoError(err)
+	requirer.Len(ptr.Brackets, 6)
+
+	from := []string{}
+	taxable := []string{}
+	tax := []string{}
+	for _, b := range ptr.Brackets {
+		from = append(from, b.From.StringWithoutSymbols())
+		taxable = append(taxable, b.Taxable.StringWithoutSymbols())
+		tax = append(tax, b.Tax.StringWithoutSymbols())
+	}
+
+	asserter.Equal([]string{"0.00", "300000.00", "700000.00", "1000000.00", "1200000.00", "1500000.00"}, from)
+	asserter.Equal([]string{"300000.00", "400000.00", "300000.00", "100000.00", "0.00", "0.00"}, taxable)
+	asserter.Equal([]string{"0.00", "20000.00", "30000.00", "15000.00", "0.00", "0.00"}, tax)
+	asserter.Equal("10%", ptr.Brackets[2].Rate.String())
+	asserter.Equal("65000.00", ptr.Tax.StringWithoutSymbols())
+
+	// 10.10 × 5% is 0.505
+	ptr, err = pt.Compute(inr(t, "300010.10"))
+	requirer.NoError(err)
+	asserter.Equal("0.51", ptr.Tax.StringWithoutSymbols())
+
+	pt.Rounding = RoundHalfEven
+	ptr, err = pt.Compute(inr(t, "300010.10"))
+	requirer.NoError(err)
+	asserter.Equal("0.50", ptr.Tax.StringWithoutSymbols())
+
+	// the exact tax of the brackets is summed & rounded once, 0.005 + 0.005
+	pt = ProgressiveTax{
+		Brackets: []TaxBracket{
+			{UpTo: inr(t, "0.10"), Rate: MustParseRate("5%")},
+			{Rate: MustParseRate("5%")},
+		},
+	}
+	ptr, err = pt.Compute(inr(t, "0.20"))
+	requirer.NoError(err)
+	asserter.Equal("0.01", ptr.Tax.StringWithoutSymbols())
+	asserter.Equal(1, ptr.Brackets[0].Tax.FractionalTotal()+ptr.Brackets[1].Tax.FractionalTotal())
+}
+
+func TestProgressiveTaxInvalid(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	pt := indianIncomeTax(t)
+	_, err := pt.Compute(&Currency{})
+	asserter.ErrorIs(err, ErrInvalidFUS)
+
+	_, err = pt.Compute(inr(t, "-1"))
+	asserter.ErrorIs(err, ErrInvalidBrackets)
+
+	usdIncome, err := New(100, 0, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+	_, err = pt.Compute(usdIncome)
+	me := &MismatchError{}
+	requirer.ErrorAs(err, &me)
+	asserter.Equal("ProgressiveTax", me.Op)
+
+	list := []ProgressiveTax{
+		{},
+		{Brackets: []TaxBracket{{UpTo: inr(t, "100")}}},
+		{Brackets: []TaxBracket{{}, {}}},
+		{Brackets: []TaxBracket{{UpTo: inr(t, "100")}, {UpTo: inr(t, "100")}, {}}},
+		{Brackets: []TaxBracket{{Rate: MustParseRate("-5%")}}},
+		{Brackets: []TaxBracket{{}}, Cess: MustParseRate("-4%")},
+		{Brackets: []TaxBracket{{}}, Surcharges: []Surcharge{{Above: *inr(t, "100"), Rate: MustParseRate("-10%")}}},
+		{Brackets: []TaxBracket{{}}, Surcharges: []Surcharge{{Above: *inr(t, "100")}, {Above: *inr(t, "50")}}},
+	}
+
+	for i, pt := range list {
+		_, err := pt.Compute(inr(t, "1000"))
+		asserter.ErrorIs(err, ErrInvalidBrackets, i)
+	}
+}
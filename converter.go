@@ -0,0 +1,196 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// RateLister is implemented by rate providers which can list all their rates, e.g. RateTable.
+// It is used by Converter to find the shortest path between 2 currencies.
+type RateLister interface {
+	Rates() []ExchangeRate
+}
+
+// CrossRate is an exchange rate derived from one or more rates.
+type CrossRate struct {
+	ExchangeRate
+	// Legs are the rates used to derive the cross rate, in order, each oriented from base to quote
+	Legs []ExchangeRate
+}
+
+// Converter converts currencies using the rates from a RateProvider. If there's no direct rate
+// between 2 currencies, it derives a cross rate through the pivot currencies, or through the
+// shortest path of available pairs if the provider implements RateLister.
+type Converter struct {
+	// Rates is the provider of the exchange rates
+	Rates RateProvider
+	// Pivots are the currencies tried in order to derive a cross rate, e.g. EUR, USD
+	Pivots []string
+	// Precision is the number of decimal places a cross rate is rounded to after every leg.
+	// 0 retains the exact rate.
+	Precision int
+	// RateRounding is the rounding mode used for rounding cross rates to Precision
+	RateRounding RoundingMode
+	// Rounding is the rounding mode used for rounding the converted amount to its fractional unit
+	Rounding RoundingMode
+}
+
+// Rate implements RateProvider, returning the direct or cross rate from base to quote.
+func (cv *Converter) Rate(ctx context.Context, base, quote string, at time.Time) (ExchangeRate, error) {
+	cr, err := cv.CrossRate(ctx, base, quote, at)
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+
+	return cr.ExchangeRate, nil
+}
+
+// CrossRate returns the rate from base to quote, along with the rates used to derive it. The
+// direct rate is preferred, then the pivots in order, and then the shortest path.
+func (cv *Converter) CrossRate(ctx context.Context, base, quote string, at time.Time) (CrossRate, error) {
+	er, err := cv.Rates.Rate(ctx, base, quote, at)
+	if err == nil {
+		return CrossRate{ExchangeRate: er, Legs: []ExchangeRate{er}}, nil
+	}
+
+	if !errors.Is(err, ErrRateNotFound) {
+		return CrossRate{}, err
+	}
+
+	for _, pivot := range cv.Pivots {
+		if pivot == base || pivot == quote {
+			continue
+		}
+
+		cr, err := cv.path(ctx, []string{base, pivot, quote}, at)
+		if err == nil {
+			return cr, nil
+		}
+
+		if !errors.Is(err, ErrRateNotFound) {
+			return CrossRate{}, err
+		}
+	}
+
+	if lister, ok := cv.Rates.(RateLister); ok {
+		codes := shortestPath(lister.Rates(), base, quote)
+		if len(codes) > 2 {
+			return cv.path(ctx, codes, at)
+		}
+	}
+
+	return CrossRate{}, fmt.Errorf("%w: %s/%s, no direct or cross rate", ErrRateNotFound, base, quote)
+}
+
+// Convert converts c to the currency `to`, using the direct or cross rate effective at the given
+// time. It also returns the rate used along with its legs, so that the conversion can be audited.
+func (cv *Converter) Convert(ctx context.Context, c *Currency, to string, at time.Time) (*Currency, CrossRate, error) {
+	if c.FUShare == 0 {
+		return nil, CrossRate{}, ErrInvalidFUS
+	}
+
+	m, err := Lookup(to)
+	if err != nil {
+		return nil, CrossRate{}, fmt.Errorf("%w: %q", err, to)
+	}
+
+	cr, err := cv.CrossRate(ctx, c.Code, to, at)
+	if err != nil {
+		return nil, CrossRate{}, err
+	}
+
+	converted, err := convert(c, m, cr.Rate, cv.Rounding)
+	if err != nil {
+		return nil, CrossRate{}, err
+	}
+
+	return converted, cr, nil
+}
+
+// path derives the cross rate through the given codes, e.g. JPY, EUR, INR
+func (cv *Converter) path(ctx context.Context, codes []string, at time.Time) (CrossRate, error) {
+	cr := CrossRate{
+		ExchangeRate: ExchangeRate{
+			Base:  codes[0],
+			Quote: codes[len(codes)-1],
+			Rate:  big.NewRat(1, 1),
+		},
+		Legs: make([]ExchangeRate, 0, len(codes)-1),
+	}
+
+	for i := 1; i < len(codes); i++ {
+		leg, err := cv.Rates.Rate(ctx, codes[i-1], codes[i], at)
+		if err != nil {
+			return CrossRate{}, err
+		}
+
+		rate, err := cv.roundRate(new(big.Rat).Mul(cr.Rate, leg.Rate))
+		if err != nil {
+			return CrossRate{}, err
+		}
+
+		cr.Rate = rate
+		cr.Legs = append(cr.Legs, leg)
+		// the cross rate is only as recent as its oldest leg
+		if i == 1 || leg.Time.Before(cr.Time) {
+			cr.Time = leg.Time
+		}
+	}
+
+	return cr, nil
+}
+
+// roundRate rounds the rate to Precision decimal places
+func (cv *Converter) roundRate(r *big.Rat) (*big.Rat, error) {
+	if cv.Precision <= 0 {
+		return r, nil
+	}
+
+	rounded, err := roundDecimals(r, cv.Precision, cv.RateRounding)
+	if err != nil {
+		return nil, err
+	}
+
+	if rounded.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %s rounds to 0 at precision %d", ErrInvalidRate, r.RatString(), cv.Precision)
+	}
+
+	return rounded, nil
+}
+
+// shortestPath returns the shortest list of codes connecting base to quote using the pairs of
+// the rates in either direction. It returns nil if there's no path.
+func shortestPath(rates []ExchangeRate, base, quote string) []string {
+	graph := make(map[string][]string)
+	for _, er := range rates {
+		graph[er.Base] = append(graph[er.Base], er.Quote)
+		graph[er.Quote] = append(graph[er.Quote], er.Base)
+	}
+
+	prev := map[string]string{base: ""}
+	queue := []string{base}
+	for len(queue) > 0 {
+		code := queue[0]
+		queue = queue[1:]
+
+		if code == quote {
+			path := []string{}
+			for c := quote; c != ""; c = prev[c] {
+				path = append([]string{c}, path...)
+			}
+			return path
+		}
+
+		for _, next := range graph[code] {
+			if _, seen := prev[next]; !seen {
+				prev[next] = code
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return nil
+}
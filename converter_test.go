@@ -0,0 +1,129 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRateTable(t *testing.T, rates ...[3]string) *RateTable {
+	rt, _ := NewRateTable()
+	for _, r := range rates {
+		er, err := NewExchangeRate(r[0], r[1], r[2], time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		require.NoError(t, rt.Set(er))
+	}
+
+	return rt
+}
+
+func TestConverterCrossRate(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	ctx := context.Background()
+	rt := newTestRateTable(t,
+		[3]string{"EUR", "JPY", "163.45"},
+		[3]string{"EUR", "INR", "90.1245"},
+		[3]string{"USD", "INR", "83.1275"},
+		[3]string{"USD", "SGD", "1.3495"},
+		[3]string{"GBP", "EUR", "1.1692"},
+	)
+
+	cv := &Converter{Rates: rt, Pivots: []string{"EUR", "USD"}}
+
+	cr, err := cv.CrossRate(ctx, "JPY", "INR", time.Now())
+	requirer.NoError(err)
+	asserter.Equal("JPY/INR 180249/326900", cr.String())
+	requirer.Len(cr.Legs, 2)
+	asserter.Equal("JPY/EUR 20/3269", cr.Legs[0].String())
+	asserter.Equal("EUR/INR 90.1245", cr.Legs[1].String())
+
+	cr, err = cv.CrossRate(ctx, "USD", "INR", time.Now())
+	requirer.NoError(err)
+	asserter.Len(cr.Legs, 1)
+	asserter.Equal("USD/INR 83.1275", cr.String())
+
+	cr, err = cv.CrossRate(ctx, "SGD", "INR", time.Now())
+	requirer.NoError(err)
+	asserter.Equal([]string{"SGD/USD", "USD/INR"}, legPairs(cr.Legs))
+
+	// GBP -> EUR -> INR -> USD -> SGD is only available through the shortest path
+	cr, err = cv.CrossRate(ctx, "GBP", "SGD", time.Now())
+	requirer.NoError(err)
+	asserter.Equal([]string{"GBP/EUR", "EUR/INR", "INR/USD", "USD/SGD"}, legPairs(cr.Legs))
+
+	_, err = cv.CrossRate(ctx, "GBP", "CHF", time.Now())
+	asserter.ErrorIs(err, ErrRateNotFound)
+
+	cv.Precision = 6
+	cv.RateRounding = RoundHalfUp
+	er, err := cv.Rate(ctx, "JPY", "INR", time.Now())
+	requirer.NoError(err)
+	asserter.Equal("JPY/INR 0.551382", er.String())
+
+	cv.Precision = 1
+	_, err = cv.Rate(ctx, "JPY", "EUR", time.Now())
+	requirer.NoError(err)
+	_, err = cv.Rate(ctx, "JPY", "GBP", time.Now())
+	asserter.ErrorIs(err, ErrInvalidRate)
+}
+
+func legPairs(legs []ExchangeRate) []string {
+	pairs := make([]string, 0, len(legs))
+	for _, leg := range legs {
+		pairs = append(pairs, leg.Base+"/"+leg.Quote)
+	}
+	return pairs
+}
+
+type failingProvider struct{}
+
+func (failingProvider) Rate(ctx context.Context, base, quote string, at time.Time) (ExchangeRate, error) {
+	return ExchangeRate{}, errors.New("rate source unavailable")
+}
+
+func TestConverterConvert(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	ctx := context.Background()
+	rt := newTestRateTable(t,
+		[3]string{"EUR", "JPY", "163.45"},
+		[3]string{"EUR", "INR", "90.1245"},
+	)
+
+	cv := &Converter{Rates: rt, Pivots: []string{"EUR"}, Precision: 6, Rounding: RoundHalfEven}
+
+	jpy, err := New(10000, 0, "JPY", "¥", "", 1)
+	requirer.NoError(err)
+
+	inr, cr, err := cv.Convert(ctx, jpy, "INR", time.Now())
+	requirer.NoError(err)
+	asserter.Equal("INR", inr.Code)
+	asserter.Equal("5513.82", inr.StringWithoutSymbols())
+	asserter.Equal([]string{"JPY/EUR", "EUR/INR"}, legPairs(cr.Legs))
+	asserter.Equal("JPY", cr.Base)
+	asserter.Equal("INR", cr.Quote)
+
+	back, _, err := cv.Convert(ctx, inr, "JPY", time.Now())
+	requirer.NoError(err)
+	asserter.Equal(10000, back.FractionalTotal())
+
+	_, _, err = cv.Convert(ctx, jpy, "XYZ", time.Now())
+	asserter.ErrorIs(err, ErrUnknownCurrency)
+
+	_, _, err = cv.Convert(ctx, &Currency{}, "INR", time.Now())
+	asserter.ErrorIs(err, ErrInvalidFUS)
+
+	_, _, err = cv.Convert(ctx, jpy, "USD", time.Now())
+	asserter.ErrorIs(err, ErrRateNotFound)
+
+	failing := &Converter{Rates: failingProvider{}, Pivots: []string{"EUR"}}
+	_, _, err = failing.Convert(ctx, jpy, "INR", time.Now())
+	asserter.EqualError(err, "rate source unavailable")
+}
@@ -20,6 +20,15 @@ var (
 
 	// ErrInvalidFUS is the error returned when Functional unit share is equal to 0
 	ErrInvalidFUS = errors.New("invalid functional unit share provided")
+
+	// ErrInvalidCode is the error returned when the currency code is not a 3 letter uppercase code
+	ErrInvalidCode = errors.New("invalid currency code provided")
+
+	// ErrInvalidFractional is the error returned when the fractional value is out of range for the currency
+	ErrInvalidFractional = errors.New("invalid fractional value provided")
+
+	// ErrInvalidAllocation is the error returned when trying to allocate a currency into less than 1 part
+	ErrInvalidAllocation = errors.New("invalid number of allocations provided")
 )
 
 // replacer is the regex which replaces all invalid characters inside a string representing a currency value
@@ -30,6 +39,12 @@ const (
 )
 
 // Currency represents money with all the meta data required.
+//
+// The zero value of Currency is an amount of 0 without any currency meta data. It can be
+// used as an accumulator, since Add and Subtract adopt the meta data of the operand when
+// called on a zero value. All other operations on the zero value return ErrInvalidFUS.
+//
+// The json tags are of the legacy JSON representation, which is still accepted by UnmarshalJSON.
 type Currency struct {
 	// Code represents the international currency code
 	Code string `json:"code,omitempty"`
@@ -69,13 +84,7 @@ func New(main int, fractional int, code, symbol, funame string, fushare uint) (*
 	m := main + (fractional / fus)
 	f := fractional % fus
 
-	fudigits := digits(fus - 1)
-
-	mag := float64(5.0)
-
-	for i := 0; i < fudigits-1; i++ {
-		mag /= 10
-	}
+	fudigits, mag := fuPrecision(fushare)
 
 	return &Currency{
 		Code:       code,
@@ -104,13 +113,7 @@ func NewFractional(ftotal int, code, symbol, funame string, fushare uint) (*Curr
 		f = -f
 	}
 
-	fudigits := digits(fus - 1)
-
-	mag := float64(5.0)
-
-	for i := 0; i < fudigits-1; i++ {
-		mag /= 10
-	}
+	fudigits, mag := fuPrecision(fushare)
 
 	return &Currency{
 		Code:       code,
@@ -142,12 +145,7 @@ func ParseFloat64(value float64, code, symbol, funame string, fushare uint) (*Cu
 		return nil, ErrInvalidFUS
 	}
 
-	fudigits := digits(fus - 1)
-
-	mag := float64(5.0)
-	for i := 0; i < fudigits-1; i++ {
-		mag /= 10
-	}
+	_, mag := fuPrecision(fushare)
 
 	ftotal := round(value*float64(fushare), mag)
 
@@ -163,6 +161,143 @@ func ParseFloat64(value float64, code, symbol, funame string, fushare uint) (*Cu
 	return New(m, f, code, symbol, funame, fushare)
 }
 
+// FieldError describes a single invalid field of a Currency.
+type FieldError struct {
+	// Field is the name of the invalid field
+	Field string
+	// Err is the reason why the field is invalid
+	Err error
+}
+
+// ValidationError is the error returned by Validate, it lists every invalid field of the currency.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (ve *ValidationError) Error() string {
+	errs := make([]string, 0, len(ve.Fields))
+	for _, fe := range ve.Fields {
+		errs = append(errs, fe.Field+": "+fe.Err.Error())
+	}
+
+	return "invalid currency, " + strings.Join(errs, "; ")
+}
+
+// Is reports whether any of the field errors match target, so that
+// errors.Is(err, ErrInvalidFUS) works on a ValidationError.
+func (ve *ValidationError) Is(target error) bool {
+	for _, fe := range ve.Fields {
+		if errors.Is(fe.Err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MismatchError is the error returned by an operation between 2 currencies which don't match,
+// i.e. they have different codes or fractional shares. It matches ErrMismatchCurrency with errors.Is.
+type MismatchError struct {
+	// Op is the name of the operation, e.g. Add
+	Op string
+	// Code & FUShare are of the currency the operation is called on
+	Code    string
+	FUShare uint
+	// OtherCode & OtherFUShare are of the other currency in the operation
+	OtherCode    string
+	OtherFUShare uint
+}
+
+// newMismatchError returns a *MismatchError for the operation op between c & other
+func newMismatchError(op string, c, other *Currency) *MismatchError {
+	return &MismatchError{
+		Op:           op,
+		Code:         c.Code,
+		FUShare:      c.FUShare,
+		OtherCode:    other.Code,
+		OtherFUShare: other.FUShare,
+	}
+}
+
+func (me *MismatchError) Error() string {
+	if me.Code == me.OtherCode {
+		return fmt.Sprintf(
+			"%s: %s: %s with fractional share %d and %s with fractional share %d",
+			ErrMismatchCurrency, me.Op, me.Code, me.FUShare, me.OtherCode, me.OtherFUShare,
+		)
+	}
+
+	return fmt.Sprintf("%s: %s: %s and %s", ErrMismatchCurrency, me.Op, me.Code, me.OtherCode)
+}
+
+// Unwrap returns ErrMismatchCurrency, so that errors.Is(err, ErrMismatchCurrency) works on a MismatchError.
+func (me *MismatchError) Unwrap() error {
+	return ErrMismatchCurrency
+}
+
+// match returns a *MismatchError for the operation op if c & other have different codes or
+// fractional shares, and ErrInvalidFUS if c has no fractional share.
+func (c *Currency) match(op string, other *Currency) error {
+	if c.FUShare == 0 {
+		return ErrInvalidFUS
+	}
+
+	if c.Code != other.Code || c.FUShare != other.FUShare {
+		return newMismatchError(op, c, other)
+	}
+
+	return nil
+}
+
+// Validate checks the meta data & value of the currency, and returns a *ValidationError
+// listing all the invalid fields. It returns nil if the currency is valid.
+func (c *Currency) Validate() error {
+	ve := &ValidationError{}
+
+	if !validCode(c.Code) {
+		ve.Fields = append(ve.Fields, FieldError{Field: "Code", Err: ErrInvalidCode})
+	}
+
+	if c.FUShare == 0 {
+		ve.Fields = append(ve.Fields, FieldError{Field: "FUShare", Err: ErrInvalidFUS})
+	} else {
+		frac := c.Fractional
+		if frac < 0 {
+			frac = -frac
+		}
+
+		if frac >= int(c.FUShare) || (c.Fractional < 0 && c.Main != 0) {
+			ve.Fields = append(ve.Fields, FieldError{Field: "Fractional", Err: ErrInvalidFractional})
+		}
+	}
+
+	if len(ve.Fields) == 0 {
+		return nil
+	}
+
+	return ve
+}
+
+// isBlank returns true if c is the zero value of Currency
+func (c *Currency) isBlank() bool {
+	return c.Code == "" && c.FUShare == 0 && c.Main == 0 && c.Fractional == 0
+}
+
+// validCode returns true if code is a 3 letter uppercase currency code
+func validCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+
+	for i := 0; i < len(code); i++ {
+		if code[i] < 'A' || code[i] > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
 // FractionalTotal returns the total value in fractional int.
 func (c *Currency) FractionalTotal() int {
 	cFrac := c.Fractional
@@ -176,6 +311,10 @@ func (c *Currency) FractionalTotal() int {
 
 // Float64 returns the currency in float64 format.
 func (c *Currency) Float64() float64 {
+	if c.FUShare == 0 {
+		return float64(c.Main)
+	}
+
 	frac := c.Fractional
 	if c.Main < 0 {
 		frac = -frac
@@ -184,17 +323,23 @@ func (c *Currency) Float64() float64 {
 	return float64(c.Main) + (float64(frac) / float64(c.FUShare))
 }
 
+// StringWithoutSymbols returns the currency represented as string, without the symbol.
 func (c *Currency) StringWithoutSymbols() string {
+	if c.FUShare == 0 {
+		return strconv.Itoa(c.Main)
+	}
+
 	frc := c.Fractional
 	if c.Fractional < 0 {
 		frc = -frc
 	}
 
+	fudigits, _ := c.precision()
 	fstr := strconv.Itoa(frc)
 
 	//all the missing digits are added to the string
-	for i := 0; i < c.fuDigits-len(fstr); i++ {
-		fstr = "0" + fstr
+	if pad := fudigits - len(fstr); pad > 0 {
+		fstr = strings.Repeat("0", pad) + fstr
 	}
 
 	str := strconv.Itoa(c.Main) + "." + fstr
@@ -257,6 +402,29 @@ func (c *Currency) Format(s fmt.State, verb rune) {
 	}
 }
 
+// precision returns the number of fractional digits and the rounding magnitude of the currency.
+// They are computed from FUShare if c was not created using one of the constructors.
+func (c *Currency) precision() (int, float64) {
+	if c.fuDigits == 0 && c.FUShare != 0 {
+		return fuPrecision(c.FUShare)
+	}
+
+	return c.fuDigits, c.magnitude
+}
+
+// fuPrecision returns the number of digits in the maximum fractional value, and the magnitude
+// used for rounding, for the given functional unit share
+func fuPrecision(fushare uint) (int, float64) {
+	fudigits := digits(int(fushare) - 1)
+
+	mag := float64(5.0)
+	for i := 0; i < fudigits-1; i++ {
+		mag /= 10
+	}
+
+	return fudigits, mag
+}
+
 // round rounds off the float value to the configured precision and returns an integer.
 func round(f float64, magnitude float64) int {
 	if math.Abs(f) < 0.5 {
@@ -247,6 +247,74 @@ func TestFormat(t *testing.T) {
 	}
 }
 
+func TestValidate(t *testing.T) {
+	asserter := assert.New(t)
+
+	valid, err := New(10, 50, "INR", "₹", "paise", 100)
+	asserter.NoError(err)
+	asserter.NoError(valid.Validate())
+
+	list := []struct {
+		Name     string
+		Currency Currency
+		Fields   []string
+		Errs     []error
+	}{
+		{
+			Name:     "zero value",
+			Currency: Currency{},
+			Fields:   []string{"Code", "FUShare"},
+			Errs:     []error{ErrInvalidCode, ErrInvalidFUS},
+		},
+		{
+			Name:     "lowercase code",
+			Currency: Currency{Code: "inr", FUShare: 100},
+			Fields:   []string{"Code"},
+			Errs:     []error{ErrInvalidCode},
+		},
+		{
+			Name:     "fractional overflow",
+			Currency: Currency{Code: "INR", Main: 1, Fractional: 100, FUShare: 100},
+			Fields:   []string{"Fractional"},
+			Errs:     []error{ErrInvalidFractional},
+		},
+		{
+			Name:     "negative fractional with main",
+			Currency: Currency{Code: "INR", Main: 1, Fractional: -5, FUShare: 100},
+			Fields:   []string{"Fractional"},
+			Errs:     []error{ErrInvalidFractional},
+		},
+		{
+			Name:     "negative fractional without main",
+			Currency: Currency{Code: "INR", Fractional: -5, FUShare: 100},
+		},
+	}
+
+	for _, l := range list {
+		err := l.Currency.Validate()
+		if len(l.Fields) == 0 {
+			asserter.NoError(err, l.Name)
+			continue
+		}
+
+		ve := &ValidationError{}
+		if !asserter.ErrorAs(err, &ve, l.Name) {
+			continue
+		}
+
+		fields := make([]string, 0, len(ve.Fields))
+		for _, fe := range ve.Fields {
+			fields = append(fields, fe.Field)
+		}
+		asserter.Equal(l.Fields, fields, l.Name)
+
+		for _, e := range l.Errs {
+			asserter.ErrorIs(err, e, l.Name)
+		}
+		asserter.NotErrorIs(err, ErrMismatchCurrency, l.Name)
+	}
+}
+
 func BenchmarkNew(t *testing.B) {
 	for i := 0; i < t.N; i++ {
 		_, _ = New(10, 50, "INR", "₹", "paise", 100)
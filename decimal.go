@@ -0,0 +1,103 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrPrecisionLoss is the error returned when a value cannot be represented exactly in the
+// fractional unit of the currency
+var ErrPrecisionLoss = errors.New("value cannot be represented exactly in the fractional unit")
+
+// plainDecimal is the grammar of a plain decimal, e.g. "-12.50"
+var plainDecimal = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$`)
+
+// parseDecimal parses a plain decimal string, e.g. "-12.50", into an exact rational.
+// Exponents, fractions, base prefixes (e.g. "0x10") and separators are not accepted.
+func parseDecimal(s string) (*big.Rat, error) {
+	s = strings.TrimSpace(s)
+	if !plainDecimal.MatchString(s) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCurrency, s)
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidCurrency, s)
+	}
+
+	return r, nil
+}
+
+// exactFractional returns the total value of r in fractional units of fushare. It returns
+// ErrPrecisionLoss if r has more precision than the fractional unit supports.
+func exactFractional(r *big.Rat, fushare uint) (int, error) {
+	ft := new(big.Rat).Mul(r, new(big.Rat).SetInt64(int64(fushare)))
+	if !ft.IsInt() {
+		return 0, fmt.Errorf("%w: %s", ErrPrecisionLoss, r.RatString())
+	}
+
+	return ratInt(ft)
+}
+
+// ratInt returns the integer value of r, which must be an integer. It returns
+// ErrInvalidCurrency if the value overflows int.
+func ratInt(r *big.Rat) (int, error) {
+	n := r.Num()
+	if !n.IsInt64() || int64(int(n.Int64())) != n.Int64() {
+		return 0, fmt.Errorf("%w: %s overflows", ErrInvalidCurrency, n.String())
+	}
+
+	return int(n.Int64()), nil
+}
+
+// rat returns the exact value of c in its main unit
+func (c *Currency) rat() *big.Rat {
+	return new(big.Rat).SetFrac64(int64(c.FractionalTotal()), int64(c.FUShare))
+}
+
+// fromRat returns a new currency with the meta data m, and the amount r in main unit rounded
+// to the fractional unit using the rounding mode.
+func fromRat(m Meta, r *big.Rat, mode RoundingMode) (*Currency, error) {
+	ft := new(big.Rat).Mul(r, new(big.Rat).SetInt64(int64(m.FUShare)))
+	ftotal, err := roundFractional(ft, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.NewFractional(ftotal)
+}
+
+// decimalString returns the amount of c as a plain decimal string with exactly as many
+// decimal places as the fractional unit, e.g. "12.50" for ₹ and "12" for ¥.
+func (c *Currency) decimalString() string {
+	if c.FUShare <= 1 {
+		return strconv.Itoa(c.FractionalTotal())
+	}
+
+	return c.StringWithoutSymbols()
+}
+
+// ParseDecimal parses a decimal string representation of the currency exactly, without
+// going through float64. It returns ErrPrecisionLoss if value has more decimal places than
+// the fractional unit supports.
+func ParseDecimal(value string, code, symbol, funame string, fushare uint) (*Currency, error) {
+	if fushare == 0 {
+		return nil, ErrInvalidFUS
+	}
+
+	r, err := parseDecimal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	ft, err := exactFractional(r, fushare)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFractional(ft, code, symbol, funame, fushare)
+}
@@ -0,0 +1,64 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDecimal(t *testing.T) {
+	asserter := assert.New(t)
+	list := []struct {
+		Value   string
+		FUShare uint
+		Total   int
+		Str     string
+		Err     error
+	}{
+		{Value: "12.50", FUShare: 100, Total: 1250, Str: "12.50"},
+		{Value: "12.5", FUShare: 100, Total: 1250, Str: "12.50"},
+		{Value: "-0.05", FUShare: 100, Total: -5, Str: "-0.05"},
+		{Value: "90071992547409.93", FUShare: 100, Total: 9007199254740993, Str: "90071992547409.93"},
+		{Value: "12.505", FUShare: 100, Err: ErrPrecisionLoss},
+		{Value: "1234", FUShare: 1, Total: 1234, Str: "1234"},
+		{Value: "1.5", FUShare: 1, Err: ErrPrecisionLoss},
+		{Value: "1e3", FUShare: 100, Err: ErrInvalidCurrency},
+		{Value: "1/3", FUShare: 100, Err: ErrInvalidCurrency},
+		{Value: "abc", FUShare: 100, Err: ErrInvalidCurrency},
+		{Value: "0x10", FUShare: 100, Err: ErrInvalidCurrency},
+		{Value: "0b11", FUShare: 100, Err: ErrInvalidCurrency},
+		{Value: "0x1p-2", FUShare: 100, Err: ErrInvalidCurrency},
+		{Value: "1_000", FUShare: 100, Err: ErrInvalidCurrency},
+		{Value: ".5", FUShare: 100, Err: ErrInvalidCurrency},
+		{Value: "+1.5", FUShare: 100, Total: 150, Str: "1.50"},
+		{Value: "", FUShare: 100, Err: ErrInvalidCurrency},
+		{Value: "99999999999999999999", FUShare: 100, Err: ErrInvalidCurrency},
+		{Value: "1", FUShare: 0, Err: ErrInvalidFUS},
+	}
+
+	for _, l := range list {
+		cur, err := ParseDecimal(l.Value, "XTS", "T", "tick", l.FUShare)
+		if l.Err != nil {
+			asserter.ErrorIs(err, l.Err, l.Value)
+			continue
+		}
+
+		if !asserter.NoError(err, l.Value) {
+			continue
+		}
+
+		asserter.Equal(l.Total, cur.FractionalTotal(), l.Value)
+		asserter.Equal(l.Str, cur.decimalString(), l.Value)
+	}
+}
+
+func TestPrecisionWithoutConstructor(t *testing.T) {
+	asserter := assert.New(t)
+
+	cur := Currency{Code: "INR", Symbol: "₹", Main: 1, Fractional: 5, FUShare: 100}
+	asserter.Equal("1.05", cur.String())
+
+	p := cur.Percent(50)
+	asserter.NotNil(p)
+	asserter.Equal(53, p.FractionalTotal())
+}
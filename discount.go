@@ -0,0 +1,198 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// ErrInvalidDiscount is the error returned when a discount is invalid, e.g. a negative amount
+var ErrInvalidDiscount = errors.New("invalid discount provided")
+
+// Discount is a discount or promotion on a set of lines, e.g. a fixed amount coupon.
+type Discount interface {
+	// Total returns the total discount on the lines, which are all in the same currency
+	Total(lines []Currency) (*Currency, error)
+}
+
+// FixedDiscount is a discount of a fixed amount, e.g. a ₹100 coupon. The discount is capped at
+// the total of the lines.
+type FixedDiscount struct {
+	Amount Currency
+}
+
+// Total implements Discount.
+func (fd FixedDiscount) Total(lines []Currency) (*Currency, error) {
+	total := linesTotal(lines)
+	err := total.match("FixedDiscount", &fd.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	if fd.Amount.FractionalTotal() < 0 {
+		return nil, fmt.Errorf("%w: negative amount %s", ErrInvalidDiscount, fd.Amount.String())
+	}
+
+	if fd.Amount.FractionalTotal() < total.FractionalTotal() {
+		total.UpdateWithFractional(fd.Amount.FractionalTotal())
+	}
+
+	return total, nil
+}
+
+// PercentDiscount is a discount of a percentage of the total of the lines, e.g. 10% off.
+type PercentDiscount struct {
+	// Rate is the discount rate, from 0% to 100%
+	Rate Rate
+	// Rounding is the rounding mode used for rounding the discount
+	Rounding RoundingMode
+}
+
+// Total implements Discount. The discount is computed on the total of the lines, and rounded once.
+func (pd PercentDiscount) Total(lines []Currency) (*Currency, error) {
+	r := pd.Rate.Rat()
+	if r.Sign() < 0 || r.Cmp(big.NewRat(1, 1)) > 0 {
+		return nil, fmt.Errorf("%w: rate %s must be from 0%% to 100%%", ErrInvalidDiscount, pd.Rate)
+	}
+
+	return pd.Rate.Apply(linesTotal(lines), pd.Rounding)
+}
+
+// BuyXGetY is a promotion where for every Buy + Get units, the Get cheapest units are free.
+// Every line is a single unit, i.e. a line is repeated for every unit bought.
+type BuyXGetY struct {
+	Buy int
+	Get int
+}
+
+// Total implements Discount. The units are grouped from the most to the least expensive, and
+// the cheapest Get units of every complete group are free.
+func (bxgy BuyXGetY) Total(lines []Currency) (*Currency, error) {
+	if bxgy.Buy < 1 || bxgy.Get < 1 {
+		return nil, fmt.Errorf("%w: buy %d get %d", ErrInvalidDiscount, bxgy.Buy, bxgy.Get)
+	}
+
+	prices := make([]int, 0, len(lines))
+	for _, l := range lines {
+		prices = append(prices, l.FractionalTotal())
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(prices)))
+
+	group := bxgy.Buy + bxgy.Get
+	free := 0
+	for start := 0; start+group <= len(prices); start += group {
+		for _, p := range prices[start+bxgy.Buy : start+group] {
+			free += p
+		}
+	}
+
+	total := linesTotal(lines)
+	total.UpdateWithFractional(free)
+	return total, nil
+}
+
+// DiscountAllocation is a discount applied to a set of lines, and its share of every line.
+type DiscountAllocation struct {
+	Discount Discount
+	// Total is the total discount
+	Total Currency
+	// Lines are the shares of the discount of every line, which add up to Total
+	Lines []Currency
+}
+
+// DiscountResult is the result of applying discounts to a set of lines.
+type DiscountResult struct {
+	// Lines are the amounts of the lines after all the discounts
+	Lines []Currency
+	// Discounts are the discounts applied in order, along with their allocation to the lines
+	Discounts []DiscountAllocation
+	// Total is the total of all the discounts
+	Total Currency
+}
+
+// ApplyDiscounts applies the discounts in order to the lines, every discount on the amounts
+// remaining after the preceding discounts. The total of every discount is allocated to the
+// lines in proportion to their remaining amounts, so that the line shares add up exactly to the
+// discount. All the lines & discounts must be in the same currency.
+func ApplyDiscounts(lines []Currency, discounts ...Discount) (*DiscountResult, error) {
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: no lines", ErrInvalidDiscount)
+	}
+
+	for i := range lines {
+		err := lines[0].match("ApplyDiscounts", &lines[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if lines[i].FractionalTotal() < 0 {
+			return nil, fmt.Errorf("%w: line %d has a negative amount", ErrInvalidDiscount, i+1)
+		}
+	}
+
+	dr := &DiscountResult{
+		Lines:     append([]Currency(nil), lines...),
+		Discounts: make([]DiscountAllocation, 0, len(discounts)),
+		Total:     *linesTotal(lines),
+	}
+	dr.Total.UpdateWithFractional(0)
+
+	for _, d := range discounts {
+		total, err := d.Total(dr.Lines)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining := linesTotal(dr.Lines)
+		err = remaining.match("ApplyDiscounts", total)
+		if err != nil {
+			return nil, err
+		}
+
+		if total.FractionalTotal() < 0 {
+			return nil, fmt.Errorf("%w: negative discount %s", ErrInvalidDiscount, total.String())
+		}
+
+		if total.FractionalTotal() > remaining.FractionalTotal() {
+			total = remaining
+		}
+
+		shares := make([]Currency, len(dr.Lines))
+		if total.FractionalTotal() == 0 {
+			for i := range shares {
+				shares[i] = *total
+			}
+		} else {
+			shares, err = total.AllocateByWeights(dr.Lines...)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for i := range dr.Lines {
+			_ = dr.Lines[i].Subtract(shares[i])
+		}
+		_ = dr.Total.Add(*total)
+
+		dr.Discounts = append(dr.Discounts, DiscountAllocation{Discount: d, Total: *total, Lines: shares})
+	}
+
+	return dr, nil
+}
+
+// linesTotal returns the sum of the lines, with the meta data of the first line
+func linesTotal(lines []Currency) *Currency {
+	total := Currency{}
+	if len(lines) > 0 {
+		total = lines[0]
+	}
+
+	ft := 0
+	for _, l := range lines {
+		ft += l.FractionalTotal()
+	}
+
+	total.UpdateWithFractional(ft)
+	return &total
+}
@@ -0,0 +1,142 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func inrLines(t *testing.T, ftotals ...int) []Currency {
+	lines := make([]Currency, 0, len(ftotals))
+	for _, ft := range ftotals {
+		c, err := NewFractional(ft, "INR", "₹", "paise", 100)
+		require.NoError(t, err)
+		lines = append(lines, *c)
+	}
+
+	return lines
+}
+
+func amounts(cs []Currency) []string {
+	strs := make([]string, 0, len(cs))
+	for _, c := range cs {
+		strs = append(strs, c.StringWithoutSymbols())
+	}
+
+	return strs
+}
+
+func TestApplyDiscountsFixed(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	coupon, err := New(100, 0, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+
+	lines := inrLines(t, 29900, 14950, 5000)
+	dr, err := ApplyDiscounts(lines, FixedDiscount{Amount: *coupon})
+	requirer.NoError(err)
+
+	// 100 × 299/498.5, 100 × 149.5/498.5, 100 × 50/498.5
+	asserter.Equal([]string{"59.98", "29.99", "10.03"}, amounts(dr.Discounts[0].Lines))
+	asserter.Equal([]string{"239.02", "119.51", "39.97"}, amounts(dr.Lines))
+	asserter.Equal("100.00", dr.Total.StringWithoutSymbols())
+	asserter.Equal("100.00", dr.Discounts[0].Total.StringWithoutSymbols())
+	// the lines are not modified
+	asserter.Equal(29900, lines[0].FractionalTotal())
+
+	// capped at the total of the lines
+	dr, err = ApplyDiscounts(inrLines(t, 3000, 1000), FixedDiscount{Amount: *coupon})
+	requirer.NoError(err)
+	asserter.Equal([]string{"0.00", "0.00"}, amounts(dr.Lines))
+	asserter.Equal("40.00", dr.Total.StringWithoutSymbols())
+}
+
+func TestApplyDiscountsStacked(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	coupon, err := New(10, 0, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+
+	dr, err := ApplyDiscounts(
+		inrLines(t, 1000, 2000, 3333),
+		PercentDiscount{Rate: MustParseRate("10%"), Rounding: RoundHalfUp},
+		FixedDiscount{Amount: *coupon},
+	)
+	requirer.NoError(err)
+
+	// 10% of 63.33 = 6.333
+	asserter.Equal("6.33", dr.Discounts[0].Total.StringWithoutSymbols())
+	asserter.Equal([]string{"1.00", "2.00", "3.33"}, amounts(dr.Discounts[0].Lines))
+	// 10 on the remaining 57.00
+	asserter.Equal([]string{"1.58", "3.16", "5.26"}, amounts(dr.Discounts[1].Lines))
+	asserter.Equal([]string{"7.42", "14.84", "24.74"}, amounts(dr.Lines))
+	asserter.Equal("16.33", dr.Total.StringWithoutSymbols())
+
+	sum := 0
+	for _, l := range dr.Lines {
+		sum += l.FractionalTotal()
+	}
+	asserter.Equal(6333-1633, sum)
+}
+
+func TestApplyDiscountsBuyXGetY(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	// buy 2 get 1, 7 units: groups of (500, 400, 300) & (300, 200, 100), 50 is left over
+	lines := inrLines(t, 30000, 10000, 50000, 20000, 5000, 40000, 30000)
+	dr, err := ApplyDiscounts(lines, BuyXGetY{Buy: 2, Get: 1})
+	requirer.NoError(err)
+	asserter.Equal("400.00", dr.Total.StringWithoutSymbols())
+
+	sum := 0
+	for _, share := range dr.Discounts[0].Lines {
+		sum += share.FractionalTotal()
+	}
+	asserter.Equal(40000, sum)
+
+	total, err := BuyXGetY{Buy: 1, Get: 1}.Total(inrLines(t, 100))
+	requirer.NoError(err)
+	asserter.Equal(0, total.FractionalTotal())
+
+	_, err = BuyXGetY{Buy: 0, Get: 1}.Total(lines)
+	asserter.ErrorIs(err, ErrInvalidDiscount)
+}
+
+func TestApplyDiscountsInvalid(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	_, err := ApplyDiscounts(nil, BuyXGetY{Buy: 1, Get: 1})
+	asserter.ErrorIs(err, ErrInvalidDiscount)
+
+	_, err = ApplyDiscounts(inrLines(t, 100, -100))
+	asserter.ErrorIs(err, ErrInvalidDiscount)
+
+	usd, err := New(10, 0, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	_, err = ApplyDiscounts(append(inrLines(t, 100), *usd))
+	me := &MismatchError{}
+	requirer.ErrorAs(err, &me)
+	asserter.Equal("ApplyDiscounts", me.Op)
+
+	_, err = ApplyDiscounts(inrLines(t, 100), FixedDiscount{Amount: *usd})
+	requirer.ErrorAs(err, &me)
+	asserter.Equal("FixedDiscount", me.Op)
+
+	negative, err := New(-10, 0, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+	_, err = ApplyDiscounts(inrLines(t, 100), FixedDiscount{Amount: *negative})
+	asserter.ErrorIs(err, ErrInvalidDiscount)
+
+	_, err = ApplyDiscounts(inrLines(t, 100), PercentDiscount{Rate: MustParseRate("101%")})
+	asserter.ErrorIs(err, ErrInvalidDiscount)
+
+	dr, err := ApplyDiscounts(inrLines(t, 0, 0), PercentDiscount{Rate: MustParseRate("10%")})
+	requirer.NoError(err)
+	asserter.Equal([]string{"0.00", "0.00"}, amounts(dr.Discounts[0].Lines))
+}
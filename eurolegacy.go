@@ -0,0 +1,146 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// ErrNotEuroLegacy is the error returned when a conversion is not between the euro and its legacy currencies
+var ErrNotEuroLegacy = errors.New("not a euro legacy currency conversion")
+
+// euroMinIntermediateDecimals is the minimum number of decimal places the intermediate euro amount
+// is rounded to while converting between 2 legacy currencies, as per Council Regulation (EC) No 1103/97
+const euroMinIntermediateDecimals = 3
+
+// euroLegacy is a euro legacy currency, with its irrevocably fixed conversion rate
+type euroLegacy struct {
+	meta Meta
+	// rate is the number of units of the legacy currency for 1 EUR, in 6 significant figures
+	rate string
+	// adopted is the date from which the conversion rate is fixed
+	adopted time.Time
+}
+
+func euroAdoption(year int) time.Time {
+	return time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// euroLegacies are the currencies replaced by the euro, keyed by code
+var euroLegacies = map[string]euroLegacy{
+	"ATS": {meta: Meta{Code: "ATS", Numeric: 40, Symbol: "S", FUName: "groschen", FUShare: 100}, rate: "13.7603", adopted: euroAdoption(1999)},
+	"BEF": {meta: Meta{Code: "BEF", Numeric: 56, Symbol: "fr.", FUName: "", FUShare: 1}, rate: "40.3399", adopted: euroAdoption(1999)},
+	"DEM": {meta: Meta{Code: "DEM", Numeric: 276, Symbol: "DM", FUName: "pfennig", FUShare: 100}, rate: "1.95583", adopted: euroAdoption(1999)},
+	"ESP": {meta: Meta{Code: "ESP", Numeric: 724, Symbol: "Pta", FUName: "", FUShare: 1}, rate: "166.386", adopted: euroAdoption(1999)},
+	"FIM": {meta: Meta{Code: "FIM", Numeric: 246, Symbol: "mk", FUName: "penni", FUShare: 100}, rate: "5.94573", adopted: euroAdoption(1999)},
+	"FRF": {meta: Meta{Code: "FRF", Numeric: 250, Symbol: "F", FUName: "centime", FUShare: 100}, rate: "6.55957", adopted: euroAdoption(1999)},
+	"IEP": {meta: Meta{Code: "IEP", Numeric: 372, Symbol: "£", FUName: "penny", FUShare: 100}, rate: "0.787564", adopted: euroAdoption(1999)},
+	"ITL": {meta: Meta{Code: "ITL", Numeric: 380, Symbol: "L.", FUName: "", FUShare: 1}, rate: "1936.27", adopted: euroAdoption(1999)},
+	"LUF": {meta: Meta{Code: "LUF", Numeric: 442, Symbol: "F", FUName: "", FUShare: 1}, rate: "40.3399", adopted: euroAdoption(1999)},
+	"NLG": {meta: Meta{Code: "NLG", Numeric: 528, Symbol: "ƒ", FUName: "cent", FUShare: 100}, rate: "2.20371", adopted: euroAdoption(1999)},
+	"PTE": {meta: Meta{Code: "PTE", Numeric: 620, Symbol: "Esc", FUName: "", FUShare: 1}, rate: "200.482", adopted: euroAdoption(1999)},
+	"GRD": {meta: Meta{Code: "GRD", Numeric: 300, Symbol: "₯", FUName: "", FUShare: 1}, rate: "340.750", adopted: euroAdoption(2001)},
+	"SIT": {meta: Meta{Code: "SIT", Numeric: 705, Symbol: "SIT", FUName: "stotin", FUShare: 100}, rate: "239.640", adopted: euroAdoption(2007)},
+	"CYP": {meta: Meta{Code: "CYP", Numeric: 196, Symbol: "£", FUName: "cent", FUShare: 100}, rate: "0.585274", adopted: euroAdoption(2008)},
+	"MTL": {meta: Meta{Code: "MTL", Numeric: 470, Symbol: "Lm", FUName: "cent", FUShare: 100}, rate: "0.429300", adopted: euroAdoption(2008)},
+	"SKK": {meta: Meta{Code: "SKK", Numeric: 703, Symbol: "Sk", FUName: "halier", FUShare: 100}, rate: "30.1260", adopted: euroAdoption(2009)},
+	"EEK": {meta: Meta{Code: "EEK", Numeric: 233, Symbol: "kr", FUName: "sent", FUShare: 100}, rate: "15.6466", adopted: euroAdoption(2011)},
+	"LVL": {meta: Meta{Code: "LVL", Numeric: 428, Symbol: "Ls", FUName: "santims", FUShare: 100}, rate: "0.702804", adopted: euroAdoption(2014)},
+	"LTL": {meta: Meta{Code: "LTL", Numeric: 440, Symbol: "Lt", FUName: "centas", FUShare: 100}, rate: "3.45280", adopted: euroAdoption(2015)},
+	"HRK": {meta: Meta{Code: "HRK", Numeric: 191, Symbol: "kn", FUName: "lipa", FUShare: 100}, rate: "7.53450", adopted: euroAdoption(2023)},
+}
+
+func init() {
+	for _, el := range euroLegacies {
+		_ = Register(el.meta)
+	}
+}
+
+// IsEuroLegacy returns true if code is a currency replaced by the euro, e.g. DEM.
+func IsEuroLegacy(code string) bool {
+	_, ok := euroLegacies[code]
+	return ok
+}
+
+// EuroLegacyRate returns the irrevocably fixed conversion rate from EUR to the legacy currency,
+// effective from the date the currency was replaced by the euro.
+func EuroLegacyRate(code string) (ExchangeRate, error) {
+	el, ok := euroLegacies[code]
+	if !ok {
+		return ExchangeRate{}, fmt.Errorf("%w: %q", ErrNotEuroLegacy, code)
+	}
+
+	return NewExchangeRate("EUR", code, el.rate, el.adopted)
+}
+
+// EuroConverter converts between the euro and its legacy currencies following Council
+// Regulation (EC) No 1103/97, i.e.
+//   - only the fixed rates in 6 significant figures are used, they are never inverted. Amounts
+//     are divided by the rate to convert to euro, and multiplied to convert from euro
+//   - conversions between 2 legacy currencies are always done through the euro, with the
+//     intermediate euro amount rounded to at least 3 decimal places
+//   - amounts are rounded to the nearest fractional unit, halves are rounded up
+//
+// The zero value is ready to use.
+type EuroConverter struct {
+	// IntermediateDecimals is the number of decimal places the intermediate euro amount is
+	// rounded to, while converting between 2 legacy currencies. Values less than 3 are treated as 3.
+	IntermediateDecimals int
+}
+
+// Convert converts c to the currency `to`, where both are either EUR or a euro legacy currency.
+func (ec EuroConverter) Convert(c *Currency, to string) (*Currency, error) {
+	if c.FUShare == 0 {
+		return nil, ErrInvalidFUS
+	}
+
+	from, fromLegacy := euroLegacies[c.Code]
+	target, toLegacy := euroLegacies[to]
+
+	if (!fromLegacy && c.Code != "EUR") || (!toLegacy && to != "EUR") {
+		return nil, fmt.Errorf("%w: %s to %s", ErrNotEuroLegacy, c.Code, to)
+	}
+
+	m, err := Lookup(to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", err, to)
+	}
+
+	amount := c.rat()
+
+	if fromLegacy {
+		amount = amount.Quo(amount, fixedRate(from))
+
+		if toLegacy {
+			decimals := ec.IntermediateDecimals
+			if decimals < euroMinIntermediateDecimals {
+				decimals = euroMinIntermediateDecimals
+			}
+
+			amount, err = roundDecimals(amount, decimals, RoundHalfUp)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if toLegacy {
+		amount = amount.Mul(amount, fixedRate(target))
+	}
+
+	nc, err := fromRat(m, amount, RoundHalfUp)
+	if err != nil {
+		return nil, err
+	}
+
+	nc.PrefixSymbol = c.PrefixSymbol
+	nc.SuffixSymbol = c.SuffixSymbol
+	return nc, nil
+}
+
+// fixedRate returns the exact fixed conversion rate of the legacy currency
+func fixedRate(el euroLegacy) *big.Rat {
+	r, _ := parseDecimal(el.rate)
+	return r
+}
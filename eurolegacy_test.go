@@ -0,0 +1,89 @@
+package currency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEuroLegacyRate(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	er, err := EuroLegacyRate("DEM")
+	requirer.NoError(err)
+	asserter.Equal("EUR/DEM 1.95583", er.String())
+	asserter.Equal(time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC), er.Time)
+
+	er, err = EuroLegacyRate("HRK")
+	requirer.NoError(err)
+	asserter.Equal("EUR/HRK 7.5345", er.String())
+	asserter.Equal(2023, er.Time.Year())
+
+	_, err = EuroLegacyRate("USD")
+	asserter.ErrorIs(err, ErrNotEuroLegacy)
+
+	asserter.True(IsEuroLegacy("ITL"))
+	asserter.False(IsEuroLegacy("EUR"))
+
+	m, err := Lookup("ITL")
+	requirer.NoError(err)
+	asserter.Equal(uint(1), m.FUShare)
+	asserter.Equal(uint16(380), m.Numeric)
+}
+
+func TestEuroConverter(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	list := []struct {
+		From     string
+		Amount   string
+		To       string
+		Decimals int
+		Expected string
+	}{
+		{From: "DEM", Amount: "100.00", To: "EUR", Expected: "51.13"},
+		{From: "EUR", Amount: "51.13", To: "DEM", Expected: "100.00"},
+		{From: "ITL", Amount: "1000", To: "EUR", Expected: "0.52"},
+		{From: "EUR", Amount: "1.00", To: "ITL", Expected: "1936"},
+		{From: "EUR", Amount: "0.01", To: "ITL", Expected: "19"},
+		{From: "FRF", Amount: "-10.00", To: "EUR", Expected: "-1.52"},
+		// 100 DEM = 51.129188 EUR, rounded to 51.129 EUR before converting to FRF
+		{From: "DEM", Amount: "100.00", To: "FRF", Expected: "335.38"},
+		{From: "DEM", Amount: "100.00", To: "FRF", Decimals: 1, Expected: "335.38"},
+		{From: "DEM", Amount: "100.00", To: "FRF", Decimals: 6, Expected: "335.39"},
+		{From: "ITL", Amount: "1000000", To: "DEM", Expected: "1010.10"},
+		{From: "EUR", Amount: "10.00", To: "EUR", Expected: "10.00"},
+	}
+
+	for _, l := range list {
+		from, err := Lookup(l.From)
+		requirer.NoError(err)
+
+		cur, err := ParseDecimal(l.Amount, from.Code, from.Symbol, from.FUName, from.FUShare)
+		requirer.NoError(err)
+
+		got, err := EuroConverter{IntermediateDecimals: l.Decimals}.Convert(cur, l.To)
+		requirer.NoError(err, l.From+" "+l.Amount+" "+l.To)
+		asserter.Equal(l.To, got.Code)
+		asserter.Equal(l.Expected, got.decimalString(), l.From+" "+l.Amount+" "+l.To)
+	}
+
+	usd, err := New(1, 0, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	dem, err := New(1, 0, "DEM", "DM", "pfennig", 100)
+	requirer.NoError(err)
+
+	_, err = EuroConverter{}.Convert(usd, "EUR")
+	asserter.ErrorIs(err, ErrNotEuroLegacy)
+
+	_, err = EuroConverter{}.Convert(dem, "USD")
+	asserter.ErrorIs(err, ErrNotEuroLegacy)
+
+	_, err = EuroConverter{}.Convert(&Currency{}, "EUR")
+	asserter.ErrorIs(err, ErrInvalidFUS)
+}
@@ -0,0 +1,93 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrInvalidFactor is the error returned when a multiplication factor or percentage is not a valid number
+var ErrInvalidFactor = errors.New("invalid factor provided")
+
+// MultiplyRat multiplies the currency by the exact factor, and rounds the product once to the
+// fractional unit using the rounding mode.
+func (c *Currency) MultiplyRat(by *big.Rat, mode RoundingMode) error {
+	if by == nil {
+		return fmt.Errorf("%w: nil", ErrInvalidFactor)
+	}
+
+	ftotal, err := c.mulRat(by, mode)
+	if err != nil {
+		return err
+	}
+
+	return c.UpdateWithFractionalE(ftotal)
+}
+
+// MultiplyDecimal multiplies the currency by the factor given as a decimal string, e.g. "0.075",
+// and rounds the product once to the fractional unit using the rounding mode.
+func (c *Currency) MultiplyDecimal(by string, mode RoundingMode) error {
+	r, err := parseFactor(by)
+	if err != nil {
+		return err
+	}
+
+	return c.MultiplyRat(r, mode)
+}
+
+// PercentRat returns a new instance of currency which is exactly n percent of c, rounded once
+// to the fractional unit using the rounding mode.
+func (c *Currency) PercentRat(n *big.Rat, mode RoundingMode) (*Currency, error) {
+	if n == nil {
+		return nil, fmt.Errorf("%w: nil", ErrInvalidFactor)
+	}
+
+	return c.scaled(new(big.Rat).Quo(n, big.NewRat(100, 1)), mode)
+}
+
+// PercentDecimal returns a new instance of currency which is exactly n percent of c, given as a
+// decimal string e.g. "7.5", rounded once to the fractional unit using the rounding mode.
+func (c *Currency) PercentDecimal(n string, mode RoundingMode) (*Currency, error) {
+	r, err := parseFactor(n)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.PercentRat(r, mode)
+}
+
+// scaled returns a new instance of currency which is c multiplied by the exact factor
+func (c *Currency) scaled(by *big.Rat, mode RoundingMode) (*Currency, error) {
+	ftotal, err := c.mulRat(by, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	c1 := *c
+	err = c1.UpdateWithFractionalE(ftotal)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c1, nil
+}
+
+// mulRat returns the fractional total of c multiplied by the exact factor, rounded using the rounding mode
+func (c *Currency) mulRat(by *big.Rat, mode RoundingMode) (int, error) {
+	if c.FUShare == 0 {
+		return 0, ErrInvalidFUS
+	}
+
+	ft := new(big.Rat).SetInt64(int64(c.FractionalTotal()))
+	return roundFractional(ft.Mul(ft, by), mode)
+}
+
+// parseFactor parses a factor given as a plain decimal string
+func parseFactor(s string) (*big.Rat, error) {
+	r, err := parseDecimal(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidFactor, s)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,87 @@
+package currency
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiplyDecimal(t *testing.T) {
+	tests := []struct {
+		name   string
+		ftotal int
+		by     string
+		mode   RoundingMode
+		want   int
+	}{
+		{name: "integer", ftotal: 1050, by: "18", mode: RoundHalfUp, want: 18900},
+		{name: "tenth", ftotal: 1050, by: "0.1", mode: RoundHalfUp, want: 105},
+		{name: "half up", ftotal: 1050, by: "0.075", mode: RoundHalfUp, want: 79},
+		{name: "half even", ftotal: 1050, by: "0.075", mode: RoundHalfEven, want: 79},
+		{name: "half even tie", ftotal: 1050, by: "0.05", mode: RoundHalfEven, want: 52},
+		{name: "floor negative", ftotal: -1050, by: "0.075", mode: RoundFloor, want: -79},
+		{name: "down negative", ftotal: -1050, by: "0.075", mode: RoundDown, want: -78},
+		{name: "many decimals", ftotal: 300, by: "33.333333", mode: RoundHalfUp, want: 10000},
+		{name: "large total", ftotal: 123456789012345678, by: "0.075", mode: RoundHalfEven, want: 9259259175925926},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cur, err := NewFractional(tt.ftotal, "INR", "₹", "paise", 100)
+			require.NoError(t, err)
+
+			require.NoError(t, cur.MultiplyDecimal(tt.by, tt.mode))
+			assert.Equal(t, tt.want, cur.FractionalTotal())
+			assert.Equal(t, "INR", cur.Code)
+		})
+	}
+}
+
+func TestPercentDecimal(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	cur, err := NewFractional(123456789012345678, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+
+	p, err := cur.PercentDecimal("7.5", RoundHalfEven)
+	requirer.NoError(err)
+	asserter.Equal(9259259175925926, p.FractionalTotal())
+	asserter.Equal(123456789012345678, cur.FractionalTotal())
+
+	p, err = cur.PercentRat(big.NewRat(1, 3), RoundDown)
+	requirer.NoError(err)
+	asserter.Equal(411522630041152, p.FractionalTotal())
+
+	small, err := New(10, 0, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+
+	p, err = small.PercentDecimal("18", RoundHalfUp)
+	requirer.NoError(err)
+	asserter.Equal("1.80", p.StringWithoutSymbols())
+
+	requirer.NoError(small.MultiplyRat(big.NewRat(2, 3), RoundCeiling))
+	asserter.Equal(667, small.FractionalTotal())
+
+	for _, invalid := range []string{"", "abc", "1e2", "1/3", "7.5%"} {
+		_, err = small.PercentDecimal(invalid, RoundHalfUp)
+		asserter.ErrorIs(err, ErrInvalidFactor, invalid)
+		asserter.ErrorIs(small.MultiplyDecimal(invalid, RoundHalfUp), ErrInvalidFactor, invalid)
+	}
+
+	_, err = small.PercentRat(nil, RoundHalfUp)
+	asserter.ErrorIs(err, ErrInvalidFactor)
+	asserter.ErrorIs(small.MultiplyRat(nil, RoundHalfUp), ErrInvalidFactor)
+
+	asserter.ErrorIs(small.MultiplyDecimal("2", RoundingMode(99)), ErrInvalidRoundingMode)
+	asserter.ErrorIs((&Currency{}).MultiplyDecimal("2", RoundHalfUp), ErrInvalidFUS)
+
+	_, err = (&Currency{}).PercentDecimal("2", RoundHalfUp)
+	asserter.ErrorIs(err, ErrInvalidFUS)
+
+	huge, err := NewFractional(1<<62, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+	asserter.ErrorIs(huge.MultiplyDecimal("4", RoundHalfUp), ErrInvalidCurrency)
+}
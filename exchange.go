@@ -0,0 +1,178 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// ErrInvalidRate is the error returned when an exchange rate is not a positive value, or has invalid codes
+var ErrInvalidRate = errors.New("invalid exchange rate provided")
+
+// ExchangeRate is the rate of exchange between 2 currencies, i.e. 1 unit of Base = Rate units of Quote.
+type ExchangeRate struct {
+	// Base is the code of the currency being converted from
+	Base string
+	// Quote is the code of the currency being converted to
+	Quote string
+	// Rate is the exact number of units of Quote for 1 unit of Base
+	Rate *big.Rat
+	// Time is the time at which the rate is effective
+	Time time.Time
+}
+
+// NewExchangeRate returns a new exchange rate given the rate as a decimal string, e.g. "83.1275".
+func NewExchangeRate(base, quote string, rate string, at time.Time) (ExchangeRate, error) {
+	r, err := parseDecimal(rate)
+	if err != nil {
+		return ExchangeRate{}, fmt.Errorf("%w: %q", ErrInvalidRate, rate)
+	}
+
+	er := ExchangeRate{Base: base, Quote: quote, Rate: r, Time: at}
+	err = er.Validate()
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+
+	return er, nil
+}
+
+// Validate checks if the codes of the exchange rate are valid, and the rate is positive.
+func (er ExchangeRate) Validate() error {
+	if !validCode(er.Base) || !validCode(er.Quote) {
+		return fmt.Errorf("%w: %q/%q: %v", ErrInvalidRate, er.Base, er.Quote, ErrInvalidCode)
+	}
+
+	if er.Rate == nil || er.Rate.Sign() <= 0 {
+		return fmt.Errorf("%w: %s/%s rate must be positive", ErrInvalidRate, er.Base, er.Quote)
+	}
+
+	return nil
+}
+
+// Inverse returns the exact inverse of the exchange rate, i.e. Quote to Base.
+func (er ExchangeRate) Inverse() ExchangeRate {
+	inv := ExchangeRate{Base: er.Quote, Quote: er.Base, Time: er.Time}
+	if er.Rate != nil && er.Rate.Sign() != 0 {
+		inv.Rate = new(big.Rat).Inv(er.Rate)
+	}
+
+	return inv
+}
+
+// clone returns a copy of er which doesn't share the rate, so that the copy can be handed out
+// without the rate being modified by its holder
+func (er ExchangeRate) clone() ExchangeRate {
+	if er.Rate != nil {
+		er.Rate = new(big.Rat).Set(er.Rate)
+	}
+
+	return er
+}
+
+// For returns the exchange rate from base to quote, inverting er if required. If the rate is
+// not of base & quote, it returns a *MismatchError between the code which is not in the rate,
+// and the code of the rate it's expected to match.
+func (er ExchangeRate) For(base, quote string) (ExchangeRate, error) {
+	switch {
+	case er.Base == base && er.Quote == quote:
+		return er, nil
+	case er.Base == quote && er.Quote == base:
+		return er.Inverse(), nil
+	}
+
+	me := &MismatchError{Op: "ExchangeRate.For", Code: base, OtherCode: er.Base}
+	switch base {
+	case er.Base:
+		me.Code, me.OtherCode = quote, er.Quote
+	case er.Quote:
+		me.Code, me.OtherCode = quote, er.Base
+	}
+
+	return ExchangeRate{}, me
+}
+
+func (er ExchangeRate) String() string {
+	rate := "<nil>"
+	if er.Rate != nil {
+		rate = ratString(er.Rate)
+	}
+
+	return er.Base + "/" + er.Quote + " " + rate
+}
+
+// Convert converts c to the currency with code `to` using the exchange rate, which can be
+// either c.Code/to or its inverse. The meta data of the target currency is taken from the
+// registry, and the converted amount is rounded to its fractional unit using mode.
+func Convert(c *Currency, to string, rate ExchangeRate, mode RoundingMode) (*Currency, error) {
+	if c.FUShare == 0 {
+		return nil, ErrInvalidFUS
+	}
+
+	err := rate.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err = rate.For(c.Code, to)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := Lookup(to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", err, to)
+	}
+
+	return convert(c, m, rate.Rate, mode)
+}
+
+// convert converts c to the currency m using the exact rate
+func convert(c *Currency, m Meta, rate *big.Rat, mode RoundingMode) (*Currency, error) {
+	nc, err := fromRat(m, new(big.Rat).Mul(c.rat(), rate), mode)
+	if err != nil {
+		return nil, err
+	}
+
+	nc.PrefixSymbol = c.PrefixSymbol
+	nc.SuffixSymbol = c.SuffixSymbol
+	return nc, nil
+}
+
+// ratString returns r as a decimal string if it has a finite decimal representation,
+// otherwise as a fraction. e.g. "83.1275" or "1/3"
+func ratString(r *big.Rat) string {
+	den := new(big.Int).Set(r.Denom())
+	places := 0
+	for _, p := range []int64{2, 5} {
+		bp := big.NewInt(p)
+		mod := new(big.Int)
+		for {
+			q, m := new(big.Int).QuoRem(den, bp, mod)
+			if m.Sign() != 0 {
+				break
+			}
+			den = q
+			places++
+		}
+	}
+
+	if den.Cmp(big.NewInt(1)) != 0 {
+		return r.RatString()
+	}
+
+	// places is an upper bound on the decimal places required, trailing zeros are trimmed
+	str := r.FloatString(places)
+	if places > 0 {
+		for str[len(str)-1] == '0' {
+			str = str[:len(str)-1]
+		}
+
+		if str[len(str)-1] == '.' {
+			str = str[:len(str)-1]
+		}
+	}
+
+	return str
+}
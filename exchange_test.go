@@ -0,0 +1,135 @@
+package currency
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExchangeRate(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	at := time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC)
+	er, err := NewExchangeRate("USD", "INR", "83.1275", at)
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83.1275", er.String())
+	asserter.Equal(at, er.Time)
+
+	inv := er.Inverse()
+	asserter.Equal("INR", inv.Base)
+	asserter.Equal("USD", inv.Quote)
+	asserter.Equal(0, new(big.Rat).Mul(er.Rate, inv.Rate).Cmp(big.NewRat(1, 1)))
+	asserter.Equal("INR/USD 400/33251", inv.String())
+
+	list := []struct {
+		Base  string
+		Quote string
+		Rate  string
+	}{
+		{Base: "USD", Quote: "INR", Rate: "0"},
+		{Base: "USD", Quote: "INR", Rate: "-1.5"},
+		{Base: "USD", Quote: "INR", Rate: "abc"},
+		{Base: "usd", Quote: "INR", Rate: "1"},
+		{Base: "USD", Quote: "", Rate: "1"},
+	}
+
+	for _, l := range list {
+		_, err := NewExchangeRate(l.Base, l.Quote, l.Rate, at)
+		asserter.ErrorIs(err, ErrInvalidRate, l.Base+"/"+l.Quote+" "+l.Rate)
+	}
+
+	asserter.Equal("USD/INR <nil>", ExchangeRate{Base: "USD", Quote: "INR"}.String())
+	asserter.Nil(ExchangeRate{Base: "USD", Quote: "INR"}.Inverse().Rate)
+}
+
+func TestConvert(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	usdinr, err := NewExchangeRate("USD", "INR", "83.1275", time.Time{})
+	requirer.NoError(err)
+
+	eurjpy, err := NewExchangeRate("EUR", "JPY", "163.45", time.Time{})
+	requirer.NoError(err)
+
+	usdkwd, err := NewExchangeRate("USD", "KWD", "0.30745", time.Time{})
+	requirer.NoError(err)
+
+	list := []struct {
+		From     string
+		Amount   string
+		To       string
+		Rate     ExchangeRate
+		Mode     RoundingMode
+		Expected string
+	}{
+		{From: "USD", Amount: "100.00", To: "INR", Rate: usdinr, Mode: RoundHalfUp, Expected: "8312.75"},
+		{From: "USD", Amount: "0.01", To: "INR", Rate: usdinr, Mode: RoundHalfUp, Expected: "0.83"},
+		{From: "USD", Amount: "0.01", To: "INR", Rate: usdinr, Mode: RoundUp, Expected: "0.84"},
+		{From: "USD", Amount: "-0.01", To: "INR", Rate: usdinr, Mode: RoundFloor, Expected: "-0.84"},
+		{From: "INR", Amount: "8312.75", To: "USD", Rate: usdinr, Mode: RoundHalfEven, Expected: "100.00"},
+		{From: "INR", Amount: "1.00", To: "USD", Rate: usdinr, Mode: RoundHalfEven, Expected: "0.01"},
+		{From: "INR", Amount: "1.00", To: "USD", Rate: usdinr, Mode: RoundDown, Expected: "0.01"},
+		{From: "INR", Amount: "0.50", To: "USD", Rate: usdinr, Mode: RoundDown, Expected: "0.00"},
+		{From: "EUR", Amount: "10.01", To: "JPY", Rate: eurjpy, Mode: RoundHalfUp, Expected: "1636"},
+		{From: "JPY", Amount: "1636", To: "EUR", Rate: eurjpy, Mode: RoundHalfUp, Expected: "10.01"},
+		{From: "USD", Amount: "12.34", To: "KWD", Rate: usdkwd, Mode: RoundHalfEven, Expected: "3.794"},
+	}
+
+	for _, l := range list {
+		from, err := Lookup(l.From)
+		requirer.NoError(err)
+
+		cur, err := ParseDecimal(l.Amount, from.Code, from.Symbol, from.FUName, from.FUShare)
+		requirer.NoError(err)
+		cur.PrefixSymbol = true
+
+		got, err := Convert(cur, l.To, l.Rate, l.Mode)
+		requirer.NoError(err)
+		asserter.Equal(l.To, got.Code)
+		asserter.Equal(l.Expected, got.decimalString(), l.From+" "+l.Amount+" "+l.Mode.String())
+		asserter.True(got.PrefixSymbol)
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	usdinr, err := NewExchangeRate("USD", "INR", "83.1275", time.Time{})
+	requirer.NoError(err)
+
+	eur, err := New(1, 0, "EUR", "€", "cent", 100)
+	requirer.NoError(err)
+
+	usd, err := New(1, 0, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	_, err = Convert(eur, "INR", usdinr, RoundHalfUp)
+	asserter.ErrorIs(err, ErrMismatchCurrency)
+	me := &MismatchError{}
+	requirer.ErrorAs(err, &me)
+	asserter.Equal(MismatchError{Op: "ExchangeRate.For", Code: "EUR", OtherCode: "USD"}, *me)
+
+	_, err = Convert(usd, "EUR", usdinr, RoundHalfUp)
+	asserter.ErrorIs(err, ErrMismatchCurrency)
+	requirer.ErrorAs(err, &me)
+	asserter.Equal(MismatchError{Op: "ExchangeRate.For", Code: "EUR", OtherCode: "INR"}, *me)
+
+	_, err = Convert(&Currency{}, "INR", usdinr, RoundHalfUp)
+	asserter.ErrorIs(err, ErrInvalidFUS)
+
+	_, err = Convert(usd, "INR", ExchangeRate{Base: "USD", Quote: "INR"}, RoundHalfUp)
+	asserter.ErrorIs(err, ErrInvalidRate)
+
+	_, err = Convert(usd, "INR", usdinr, RoundingMode(99))
+	asserter.ErrorIs(err, ErrInvalidRoundingMode)
+
+	usdxts := ExchangeRate{Base: "USD", Quote: "XYZ", Rate: big.NewRat(1, 1)}
+	_, err = Convert(usd, "XYZ", usdxts, RoundHalfUp)
+	asserter.ErrorIs(err, ErrUnknownCurrency)
+}
@@ -0,0 +1,349 @@
+package currency
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// ErrInvalidFeeSchedule is the error returned when a fee schedule is invalid, e.g. tiers out of order
+var ErrInvalidFeeSchedule = errors.New("invalid fee schedule provided")
+
+// FeeTier is a tier of a fee schedule, which applies to amounts up to UpTo.
+type FeeTier struct {
+	// UpTo is the inclusive upper bound of the tier, nil if it has no upper bound
+	UpTo *Currency
+	// Percent is the rate of the fee in the tier
+	Percent Rate
+	// Fixed is the fixed fee in the tier
+	Fixed Currency
+}
+
+// FeeSchedule is a composite fee, e.g. 2.9% + 30¢, min 50¢, max $10, with optional volume
+// tiers. It can be loaded from a JSON config with LoadFeeSchedule.
+//
+// The fee is the sum of the percentage & fixed components, and of the tiers, limited to Min &
+// Max. All the percentages are computed exactly, and their sum is rounded once. The fee is never
+// more than the amount, e.g. a minimum fee of 50¢ on an amount of 20¢ is capped at 20¢, so that
+// the net amount is never negative.
+type FeeSchedule struct {
+	// Percent is the rate of the fee on the whole amount
+	Percent Rate
+	// Fixed is the fixed fee. The zero value of Currency means no fixed fee.
+	Fixed Currency
+	// Min is the minimum fee, nil if there's no minimum
+	Min *Currency
+	// Max is the maximum fee, nil if there's no maximum
+	Max *Currency
+	// Tiers are in increasing order of UpTo, where only the last tier can be without an upper bound
+	Tiers []FeeTier
+	// Banded if true, charges every band of the amount as per its tier, i.e. the part of the
+	// amount up to the first tier's UpTo as per the first tier, the part above it & up to the
+	// second tier's UpTo as per the second tier, and so on. The fixed fees of all the tiers
+	// reached are charged. Otherwise, the whole amount is charged as per the tier it falls in.
+	Banded bool
+	// Rounding is the rounding mode used for rounding the fee
+	Rounding RoundingMode
+}
+
+// FeeResult is the fee charged on an amount.
+type FeeResult struct {
+	// Amount is the amount the fee is charged on
+	Amount Currency
+	// Fee is the fee charged
+	Fee Currency
+	// Net is Amount - Fee, which is never negative
+	Net Currency
+}
+
+// Apply computes the fee on the amount, which must not be negative. The fee is capped at the amount.
+func (fs *FeeSchedule) Apply(amount *Currency) (*FeeResult, error) {
+	if amount.FUShare == 0 {
+		return nil, ErrInvalidFUS
+	}
+
+	if amount.FractionalTotal() < 0 {
+		return nil, fmt.Errorf("%w: negative amount %s", ErrInvalidFeeSchedule, amount.String())
+	}
+
+	err := fs.validate(amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ft := amount.FractionalTotal()
+	percent := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(ft)), fs.Percent.Rat())
+	fixed := fs.Fixed.FractionalTotal()
+
+	lower := 0
+	for _, tier := range fs.Tiers {
+		inTier := tier.UpTo == nil || ft <= tier.UpTo.FractionalTotal()
+		base := ft
+		if fs.Banded {
+			if !inTier {
+				base = tier.UpTo.FractionalTotal()
+			}
+			base, lower = base-lower, base
+		}
+
+		if fs.Banded || inTier {
+			charged := new(big.Rat).SetInt64(int64(base))
+			percent.Add(percent, charged.Mul(charged, tier.Percent.Rat()))
+			fixed += tier.Fixed.FractionalTotal()
+		}
+
+		if inTier {
+			break
+		}
+	}
+
+	fee, err := roundFractional(percent, fs.Rounding)
+	if err != nil {
+		return nil, err
+	}
+	fee += fixed
+
+	if fs.Min != nil && fee < fs.Min.FractionalTotal() {
+		fee = fs.Min.FractionalTotal()
+	}
+
+	if fs.Max != nil && fee > fs.Max.FractionalTotal() {
+		fee = fs.Max.FractionalTotal()
+	}
+
+	if fee > ft {
+		fee = ft
+	}
+
+	fr := &FeeResult{Amount: *amount, Fee: *amount, Net: *amount}
+	err = fr.Fee.UpdateWithFractionalE(fee)
+	if err != nil {
+		return nil, err
+	}
+
+	err = fr.Net.UpdateWithFractionalE(ft - fee)
+	if err != nil {
+		return nil, err
+	}
+
+	return fr, nil
+}
+
+// validate checks that all the amounts of the schedule match the currency of amount, and that
+// the limits & tiers are in order
+func (fs *FeeSchedule) validate(amount *Currency) error {
+	amounts := []*Currency{&fs.Fixed, fs.Min, fs.Max}
+	for i := range fs.Tiers {
+		amounts = append(amounts, fs.Tiers[i].UpTo, &fs.Tiers[i].Fixed)
+	}
+
+	for _, c := range amounts {
+		if c == nil || c.isBlank() {
+			continue
+		}
+
+		err := amount.match("FeeSchedule", c)
+		if err != nil {
+			return err
+		}
+	}
+
+	if fs.Min != nil && fs.Max != nil && fs.Min.FractionalTotal() > fs.Max.FractionalTotal() {
+		return fmt.Errorf("%w: min %s is more than max %s", ErrInvalidFeeSchedule, fs.Min.String(), fs.Max.String())
+	}
+
+	for i, tier := range fs.Tiers {
+		if tier.UpTo == nil {
+			if i != len(fs.Tiers)-1 {
+				return fmt.Errorf("%w: only the last tier can be without an upper bound", ErrInvalidFeeSchedule)
+			}
+			continue
+		}
+
+		if i > 0 && fs.Tiers[i-1].UpTo != nil && tier.UpTo.FractionalTotal() <= fs.Tiers[i-1].UpTo.FractionalTotal() {
+			return fmt.Errorf("%w: tier %d is not above tier %d", ErrInvalidFeeSchedule, i+1, i)
+		}
+	}
+
+	return nil
+}
+
+// feeTierJSON is the JSON config of a fee tier
+type feeTierJSON struct {
+	UpTo    string `json:"upTo,omitempty"`
+	Percent Rate   `json:"percent"`
+	Fixed   string `json:"fixed,omitempty"`
+}
+
+// feeScheduleJSON is the JSON config of a fee schedule, where the amounts are decimal strings
+// in the currency of the schedule
+type feeScheduleJSON struct {
+	Currency string        `json:"currency,omitempty"`
+	Percent  Rate          `json:"percent"`
+	Fixed    string        `json:"fixed,omitempty"`
+	Min      string        `json:"min,omitempty"`
+	Max      string        `json:"max,omitempty"`
+	Tiers    []feeTierJSON `json:"tiers,omitempty"`
+	Banded   bool          `json:"banded,omitempty"`
+	Rounding RoundingMode  `json:"rounding"`
+}
+
+// LoadFeeSchedule loads a fee schedule from a JSON config, where the amounts are decimal
+// strings in the currency of the schedule, and the rates are in any of the formats of
+// ParseRate. e.g.
+//
+//	{
+//		"currency": "USD",
+//		"fixed": "0.30",
+//		"min": "0.50",
+//		"max": "10.00",
+//		"tiers": [
+//			{"upTo": "1000.00", "percent": "2.9%"},
+//			{"percent": "2.5%"}
+//		],
+//		"rounding": "HalfUp"
+//	}
+//
+// The currency must be in the registry.
+func LoadFeeSchedule(r io.Reader) (*FeeSchedule, error) {
+	fs := &FeeSchedule{}
+	err := json.NewDecoder(r).Decode(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	return fs, nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the schedule as the JSON config of
+// LoadFeeSchedule. All the amounts must be in the same currency, and the rates must have a
+// finite decimal representation.
+func (fs FeeSchedule) MarshalJSON() ([]byte, error) {
+	cfg := feeScheduleJSON{Percent: fs.Percent, Banded: fs.Banded, Rounding: fs.Rounding}
+
+	var first *Currency
+	format := func(c *Currency) (string, error) {
+		if c == nil || c.isBlank() {
+			return "", nil
+		}
+
+		if first == nil {
+			first = c
+		}
+
+		err := first.match("FeeSchedule", c)
+		if err != nil {
+			return "", err
+		}
+
+		return c.decimalString(), nil
+	}
+
+	var err error
+	for _, f := range []struct {
+		c   *Currency
+		str *string
+	}{{&fs.Fixed, &cfg.Fixed}, {fs.Min, &cfg.Min}, {fs.Max, &cfg.Max}} {
+		*f.str, err = format(f.c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, t := range fs.Tiers {
+		tier := feeTierJSON{Percent: t.Percent}
+		tier.UpTo, err = format(t.UpTo)
+		if err != nil {
+			return nil, err
+		}
+
+		tier.Fixed, err = format(&t.Fixed)
+		if err != nil {
+			return nil, err
+		}
+
+		cfg.Tiers = append(cfg.Tiers, tier)
+	}
+
+	if first != nil {
+		cfg.Currency = first.Code
+	}
+
+	return json.Marshal(cfg)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the JSON config of LoadFeeSchedule.
+func (fs *FeeSchedule) UnmarshalJSON(data []byte) error {
+	cfg := feeScheduleJSON{}
+	err := json.Unmarshal(data, &cfg)
+	if err != nil {
+		return err
+	}
+
+	m := Meta{}
+	if cfg.Currency != "" {
+		m, err = Lookup(cfg.Currency)
+		if err != nil {
+			return fmt.Errorf("%w: fee schedule currency %q", err, cfg.Currency)
+		}
+	}
+
+	parse := func(name, value string) (*Currency, error) {
+		if value == "" {
+			return nil, nil
+		}
+
+		if m.FUShare == 0 {
+			return nil, fmt.Errorf("%w: %s: missing currency", ErrInvalidFeeSchedule, name)
+		}
+
+		c, err := ParseDecimal(value, m.Code, m.Symbol, m.FUName, m.FUShare)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidFeeSchedule, name, err)
+		}
+
+		return c, nil
+	}
+
+	nfs := FeeSchedule{Percent: cfg.Percent, Banded: cfg.Banded, Rounding: cfg.Rounding}
+	fixed, err := parse("fixed", cfg.Fixed)
+	if err != nil {
+		return err
+	}
+	if fixed != nil {
+		nfs.Fixed = *fixed
+	}
+
+	nfs.Min, err = parse("min", cfg.Min)
+	if err != nil {
+		return err
+	}
+
+	nfs.Max, err = parse("max", cfg.Max)
+	if err != nil {
+		return err
+	}
+
+	for i, t := range cfg.Tiers {
+		tier := FeeTier{Percent: t.Percent}
+		tier.UpTo, err = parse(fmt.Sprintf("tier %d upTo", i+1), t.UpTo)
+		if err != nil {
+			return err
+		}
+
+		fixed, err := parse(fmt.Sprintf("tier %d fixed", i+1), t.Fixed)
+		if err != nil {
+			return err
+		}
+		if fixed != nil {
+			tier.Fixed = *fixed
+		}
+
+		nfs.Tiers = append(nfs.Tiers, tier)
+	}
+
+	*fs = nfs
+	return nil
+}
@@ -0,0 +1,295 @@
+package currency
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func usd(t *testing.T, value string) *Currency {
+	c, err := ParseDecimal(value, "USD", "$", "cent", 100)
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestFeeSchedule(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	// 2.9% + 30¢, min 50¢, max $10
+	fs := FeeSchedule{
+		Percent: MustParseRate("2.9%"),
+		Fixed:   *usd(t, "0.30"),
+		Min:     usd(t, "0.50"),
+		Max:     usd(t, "10.00"),
+	}
+
+	list := []struct {
+		Amount string
+		Fee    string
+		Net    string
+	}{
+		{Amount: "100.00", Fee: "3.20", Net: "96.80"},
+		// 0.30595 rounded to 0.31, plus 0.30
+		{Amount: "10.55", Fee: "0.61", Net: "9.94"},
+		{Amount: "1.00", Fee: "0.50", Net: "0.50"},
+		{Amount: "1000.00", Fee: "10.00", Net: "990.00"},
+		// the fee is capped at the amount
+		{Amount: "0.20", Fee: "0.20", Net: "0.00"},
+		{Amount: "0.45", Fee: "0.45", Net: "0.00"},
+		{Amount: "0.00", Fee: "0.00", Net: "0.00"},
+	}
+
+	for _, l := range list {
+		fr, err := fs.Apply(usd(t, l.Amount))
+		requirer.NoError(err, l.Amount)
+		asserter.Equal(l.Amount, fr.Amount.StringWithoutSymbols())
+		asserter.Equal(l.Fee, fr.Fee.StringWithoutSymbols(), l.Amount)
+		asserter.Equal(l.Net, fr.Net.StringWithoutSymbols(), l.Amount)
+		asserter.Equal("USD", fr.Fee.Code)
+	}
+}
+
+func TestFeeScheduleTiers(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	fs := FeeSchedule{
+		Fixed: *usd(t, "0.30"),
+		Tiers: []FeeTier{
+			{UpTo: usd(t, "1000.00"), Percent: MustParseRate("2.9%")},
+			{Percent: MustParseRate("2.5%")},
+		},
+	}
+
+	fr, err := fs.Apply(usd(t, "1000.00"))
+	requirer.NoError(err)
+	asserter.Equal("29.30", fr.Fee.StringWithoutSymbols())
+
+	// the whole amount is charged as per the 2nd tier
+	fr, err = fs.Apply(usd(t, "2000.00"))
+	requirer.NoError(err)
+	asserter.Equal("50.30", fr.Fee.StringWithoutSymbols())
+
+	// 1000 × 2.9% + 1000 × 2.5%
+	fs.Banded = true
+	fr, err = fs.Apply(usd(t, "2000.00"))
+	requirer.NoError(err)
+	asserter.Equal("54.30", fr.Fee.StringWithoutSymbols())
+
+	fs = FeeSchedule{
+		Fixed: *usd(t, "0.30"),
+		Tiers: []FeeTier{
+			{UpTo: usd(t, "100.00"), Percent: MustParseRate("1%"), Fixed: *usd(t, "0.10")},
+			{UpTo: usd(t, "200.00"), Percent: MustParseRate("2%"), Fixed: *usd(t, "0.20")},
+		},
+		Banded: true,
+	}
+
+	list := []struct {
+		Amount string
+		Fee    string
+	}{
+		{Amount: "50.00", Fee: "0.90"},
+		// 1.00 + 0.10 + 1.00 + 0.20 + 0.30
+		{Amount: "150.00", Fee: "2.60"},
+		// the amount above the last tier is not charged
+		{Amount: "300.00", Fee: "3.60"},
+	}
+
+	for _, l := range list {
+		fr, err := fs.Apply(usd(t, l.Amount))
+		requirer.NoError(err, l.Amount)
+		asserter.Equal(l.Fee, fr.Fee.StringWithoutSymbols(), l.Amount)
+	}
+
+	// the bands are summed exactly & rounded once, 0.0025 + 0.0025
+	fs = FeeSchedule{
+		Tiers: []FeeTier{
+			{UpTo: usd(t, "0.25"), Percent: MustParseRate("1%")},
+			{Percent: MustParseRate("1%")},
+		},
+		Banded: true,
+	}
+	fr, err = fs.Apply(usd(t, "0.50"))
+	requirer.NoError(err)
+	asserter.Equal("0.01", fr.Fee.StringWithoutSymbols())
+
+	fs.Rounding = RoundDown
+	fr, err = fs.Apply(usd(t, "0.50"))
+	requirer.NoError(err)
+	asserter.Equal("0.00", fr.Fee.StringWithoutSymbols())
+}
+
+func TestFeeScheduleInvalid(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	fs := FeeSchedule{Percent: MustParseRate("1%")}
+	_, err := fs.Apply(&Currency{})
+	asserter.ErrorIs(err, ErrInvalidFUS)
+
+	_, err = fs.Apply(usd(t, "-1.00"))
+	asserter.ErrorIs(err, ErrInvalidFeeSchedule)
+
+	fs = FeeSchedule{Min: usd(t, "2.00"), Max: usd(t, "1.00")}
+	_, err = fs.Apply(usd(t, "1.00"))
+	asserter.ErrorIs(err, ErrInvalidFeeSchedule)
+
+	fs = FeeSchedule{Tiers: []FeeTier{{}, {UpTo: usd(t, "1.00")}}}
+	_, err = fs.Apply(usd(t, "1.00"))
+	asserter.ErrorIs(err, ErrInvalidFeeSchedule)
+
+	fs = FeeSchedule{Tiers: []FeeTier{{UpTo: usd(t, "2.00")}, {UpTo: usd(t, "2.00")}}}
+	_, err = fs.Apply(usd(t, "1.00"))
+	asserter.ErrorIs(err, ErrInvalidFeeSchedule)
+
+	eur, err := New(0, 30, "EUR", "€", "cent", 100)
+	requirer.NoError(err)
+	fs = FeeSchedule{Tiers: []FeeTier{{Fixed: *eur}}}
+	_, err = fs.Apply(usd(t, "1.00"))
+	me := &MismatchError{}
+	requirer.ErrorAs(err, &me)
+	asserter.Equal("FeeSchedule", me.Op)
+	asserter.ErrorIs(err, ErrMismatchCurrency)
+}
+
+func TestLoadFeeSchedule(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	cfg := `{
+		"currency": "USD",
+		"fixed": "0.30",
+		"min": "0.50",
+		"max": "10.00",
+		"tiers": [
+			{"upTo": "1000.00", "percent": "2.9%"},
+			{"percent": "250bps", "fixed": "0.05"}
+		],
+		"banded": true,
+		"rounding": "HalfEven"
+	}`
+
+	fs, err := LoadFeeSchedule(strings.NewReader(cfg))
+	requirer.NoError(err)
+	asserter.Equal("0.30", fs.Fixed.StringWithoutSymbols())
+	asserter.Equal("0.50", fs.Min.StringWithoutSymbols())
+	asserter.Equal("10.00", fs.Max.StringWithoutSymbols())
+	asserter.True(fs.Percent.IsZero())
+	asserter.True(fs.Banded)
+	asserter.Equal(RoundHalfEven, fs.Rounding)
+	requirer.Len(fs.Tiers, 2)
+	asserter.Equal("1000.00", fs.Tiers[0].UpTo.StringWithoutSymbols())
+	asserter.Nil(fs.Tiers[1].UpTo)
+	asserter.Equal("2.5%", fs.Tiers[1].Percent.String())
+	asserter.Equal("0.05", fs.Tiers[1].Fixed.StringWithoutSymbols())
+
+	list := []struct {
+		Amount string
+		Fee    string
+	}{
+		{Amount: "5.00", Fee: "0.50"},
+		// 0.725 rounded half to even, plus 0.30
+		{Amount: "25.00", Fee: "1.02"},
+		{Amount: "100.00", Fee: "3.20"},
+		{Amount: "2000.00", Fee: "10.00"},
+	}
+
+	for _, l := range list {
+		fr, err := fs.Apply(usd(t, l.Amount))
+		requirer.NoError(err, l.Amount)
+		asserter.Equal(l.Fee, fr.Fee.StringWithoutSymbols(), l.Amount)
+	}
+
+	invalid := []string{
+		`{"currency": "XXX"}`,
+		`{"currency": "USD", "fixed": "abc"}`,
+		`{"currency": "USD", "tiers": [{"upTo": "1.2.3"}]}`,
+		`{"currency": "USD", "percent": "lots"}`,
+		`{"currency": "USD", "rounding": "Sideways"}`,
+		`[]`,
+	}
+
+	for _, cfg := range invalid {
+		_, err := LoadFeeSchedule(strings.NewReader(cfg))
+		asserter.Error(err, cfg)
+	}
+
+	_, err = LoadFeeSchedule(strings.NewReader(`{"currency": "USD", "min": "x"}`))
+	asserter.ErrorIs(err, ErrInvalidFeeSchedule)
+
+	_, err = LoadFeeSchedule(strings.NewReader(`{"currency": "XXX"}`))
+	asserter.ErrorIs(err, ErrUnknownCurrency)
+}
+
+func TestFeeScheduleJSON(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	fs := FeeSchedule{
+		Percent: MustParseRate("0.5%"),
+		Fixed:   *usd(t, "0.30"),
+		Min:     usd(t, "0.50"),
+		Tiers: []FeeTier{
+			{UpTo: usd(t, "1000.00"), Percent: MustParseRate("2.9%")},
+			{Percent: MustParseRate("2.5%"), Fixed: *usd(t, "0.05")},
+		},
+		Banded:   true,
+		Rounding: RoundHalfEven,
+	}
+
+	data, err := json.Marshal(fs)
+	requirer.NoError(err)
+	asserter.JSONEq(`{
+		"currency": "USD",
+		"percent": "0.5%",
+		"fixed": "0.30",
+		"min": "0.50",
+		"tiers": [
+			{"upTo": "1000.00", "percent": "2.9%"},
+			{"percent": "2.5%", "fixed": "0.05"}
+		],
+		"banded": true,
+		"rounding": "HalfEven"
+	}`, string(data))
+
+	decoded, err := LoadFeeSchedule(strings.NewReader(string(data)))
+	requirer.NoError(err)
+
+	for _, amount := range []string{"0.00", "25.00", "1000.00", "2500.00"} {
+		want, err := fs.Apply(usd(t, amount))
+		requirer.NoError(err)
+		got, err := decoded.Apply(usd(t, amount))
+		requirer.NoError(err)
+		asserter.Equal(want.Fee.StringWithoutSymbols(), got.Fee.StringWithoutSymbols(), amount)
+	}
+
+	again, err := json.Marshal(decoded)
+	requirer.NoError(err)
+	asserter.JSONEq(string(data), string(again))
+
+	// a schedule of only rates has no currency
+	data, err = json.Marshal(FeeSchedule{Percent: MustParseRate("1%")})
+	requirer.NoError(err)
+	asserter.JSONEq(`{"percent": "1%", "rounding": "HalfUp"}`, string(data))
+	decoded, err = LoadFeeSchedule(strings.NewReader(string(data)))
+	requirer.NoError(err)
+	asserter.Equal("1%", decoded.Percent.String())
+
+	_, err = LoadFeeSchedule(strings.NewReader(`{"fixed": "0.30"}`))
+	asserter.ErrorIs(err, ErrInvalidFeeSchedule)
+
+	eur, err := New(0, 30, "EUR", "€", "cent", 100)
+	requirer.NoError(err)
+	_, err = json.Marshal(FeeSchedule{Fixed: *eur, Max: usd(t, "10.00")})
+	asserter.ErrorIs(err, ErrMismatchCurrency)
+
+	_, err = json.Marshal(FeeSchedule{Percent: NewRate(big.NewRat(1, 3))})
+	asserter.ErrorIs(err, ErrInvalidFactor)
+}
@@ -0,0 +1,118 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrInvalidGoogleMoney is the error returned when a GoogleMoney value violates the rules of google.type.Money
+var ErrInvalidGoogleMoney = errors.New("invalid google.type.Money value")
+
+// nanosPerUnit is the number of nano units that make up 1 unit in google.type.Money
+const nanosPerUnit = 1000000000
+
+// GoogleMoney mirrors the google.type.Money protobuf message, without depending on protobuf.
+type GoogleMoney struct {
+	// CurrencyCode is the ISO 4217 currency code
+	CurrencyCode string
+	// Units is the whole units of the amount
+	Units int64
+	// Nanos is the number of nano (10^-9) units of the amount, in the range [-999,999,999, +999,999,999].
+	// It must have the same sign as Units, or Units must be 0.
+	Nanos int32
+}
+
+// Validate checks the sign & range rules of google.type.Money.
+func (gm GoogleMoney) Validate() error {
+	if gm.Nanos <= -nanosPerUnit || gm.Nanos >= nanosPerUnit {
+		return fmt.Errorf("%w: nanos %d out of range", ErrInvalidGoogleMoney, gm.Nanos)
+	}
+
+	if (gm.Units > 0 && gm.Nanos < 0) || (gm.Units < 0 && gm.Nanos > 0) {
+		return fmt.Errorf("%w: units %d and nanos %d have different signs", ErrInvalidGoogleMoney, gm.Units, gm.Nanos)
+	}
+
+	return nil
+}
+
+// rat returns the exact value of gm
+func (gm GoogleMoney) rat() *big.Rat {
+	r := new(big.Rat).SetFrac64(int64(gm.Nanos), nanosPerUnit)
+	return r.Add(r, new(big.Rat).SetInt64(gm.Units))
+}
+
+// ToGoogleMoney converts c to GoogleMoney. It returns ErrPrecisionLoss if the fractional unit
+// of c cannot be represented exactly in nanos.
+func ToGoogleMoney(c *Currency) (GoogleMoney, error) {
+	if c.FUShare == 0 {
+		return GoogleMoney{}, ErrInvalidFUS
+	}
+
+	ftotal := int64(c.FractionalTotal())
+	fus := int64(c.FUShare)
+
+	rem := ftotal % fus
+	nanos := new(big.Rat).SetFrac64(rem*nanosPerUnit, fus)
+	if !nanos.IsInt() {
+		return GoogleMoney{}, fmt.Errorf("%w: %d/%d in nanos", ErrPrecisionLoss, rem, fus)
+	}
+
+	return GoogleMoney{
+		CurrencyCode: c.Code,
+		Units:        ftotal / fus,
+		Nanos:        int32(nanos.Num().Int64()),
+	}, nil
+}
+
+// FromGoogleMoney converts gm to a currency, with the meta data from the registry. It returns
+// ErrPrecisionLoss if gm cannot be represented exactly in the fractional unit of the currency.
+func FromGoogleMoney(gm GoogleMoney) (*Currency, error) {
+	m, r, err := gm.fractional()
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.IsInt() {
+		return nil, fmt.Errorf("%w: %s %s", ErrPrecisionLoss, gm.CurrencyCode, gm.rat().FloatString(9))
+	}
+
+	ftotal, err := ratInt(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.NewFractional(ftotal)
+}
+
+// FromGoogleMoneyRounded converts gm to a currency, with the meta data from the registry. If gm
+// cannot be represented exactly in the fractional unit of the currency, it is rounded using mode.
+func FromGoogleMoneyRounded(gm GoogleMoney, mode RoundingMode) (*Currency, error) {
+	m, r, err := gm.fractional()
+	if err != nil {
+		return nil, err
+	}
+
+	ftotal, err := roundFractional(r, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.NewFractional(ftotal)
+}
+
+// fractional returns the meta data of the currency of gm, and the exact value of gm in its fractional unit
+func (gm GoogleMoney) fractional() (Meta, *big.Rat, error) {
+	err := gm.Validate()
+	if err != nil {
+		return Meta{}, nil, err
+	}
+
+	m, err := Lookup(gm.CurrencyCode)
+	if err != nil {
+		return Meta{}, nil, fmt.Errorf("%w: %q", err, gm.CurrencyCode)
+	}
+
+	r := gm.rat()
+	return m, r.Mul(r, new(big.Rat).SetInt64(int64(m.FUShare))), nil
+}
@@ -0,0 +1,105 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToGoogleMoney(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	list := []struct {
+		Total    int
+		Code     string
+		FUShare  uint
+		Expected GoogleMoney
+		Err      error
+	}{
+		{Total: 1250, Code: "USD", FUShare: 100, Expected: GoogleMoney{CurrencyCode: "USD", Units: 12, Nanos: 500000000}},
+		{Total: -1250, Code: "USD", FUShare: 100, Expected: GoogleMoney{CurrencyCode: "USD", Units: -12, Nanos: -500000000}},
+		{Total: -5, Code: "USD", FUShare: 100, Expected: GoogleMoney{CurrencyCode: "USD", Units: 0, Nanos: -50000000}},
+		{Total: 1001, Code: "KWD", FUShare: 1000, Expected: GoogleMoney{CurrencyCode: "KWD", Units: 1, Nanos: 1000000}},
+		{Total: 1200, Code: "JPY", FUShare: 1, Expected: GoogleMoney{CurrencyCode: "JPY", Units: 1200}},
+		{Total: 1, Code: "XTS", FUShare: 3, Err: ErrPrecisionLoss},
+	}
+
+	for _, l := range list {
+		cur, err := NewFractional(l.Total, l.Code, "", "", l.FUShare)
+		requirer.NoError(err)
+
+		gm, err := ToGoogleMoney(cur)
+		if l.Err != nil {
+			asserter.ErrorIs(err, l.Err)
+			continue
+		}
+
+		requirer.NoError(err)
+		asserter.Equal(l.Expected, gm)
+		asserter.NoError(gm.Validate())
+
+		back, err := FromGoogleMoney(gm)
+		requirer.NoError(err)
+		asserter.Equal(l.Total, back.FractionalTotal())
+	}
+
+	_, err := ToGoogleMoney(&Currency{})
+	asserter.ErrorIs(err, ErrInvalidFUS)
+}
+
+func TestFromGoogleMoney(t *testing.T) {
+	asserter := assert.New(t)
+
+	list := []struct {
+		Money   GoogleMoney
+		Mode    RoundingMode
+		Exact   int
+		Rounded int
+		Err     error
+	}{
+		{Money: GoogleMoney{CurrencyCode: "INR", Units: 10, Nanos: 500000000}, Exact: 1050, Rounded: 1050},
+		{Money: GoogleMoney{CurrencyCode: "INR", Units: 10, Nanos: 505000000}, Mode: RoundHalfEven, Err: ErrPrecisionLoss, Rounded: 1050},
+		{Money: GoogleMoney{CurrencyCode: "INR", Units: 10, Nanos: 505000000}, Mode: RoundHalfUp, Err: ErrPrecisionLoss, Rounded: 1051},
+		{Money: GoogleMoney{CurrencyCode: "INR", Units: -10, Nanos: -505000000}, Mode: RoundHalfUp, Err: ErrPrecisionLoss, Rounded: -1051},
+		{Money: GoogleMoney{CurrencyCode: "INR", Units: -10, Nanos: -501000000}, Mode: RoundFloor, Err: ErrPrecisionLoss, Rounded: -1051},
+		{Money: GoogleMoney{CurrencyCode: "JPY", Units: 100, Nanos: 990000000}, Mode: RoundDown, Err: ErrPrecisionLoss, Rounded: 100},
+	}
+
+	for _, l := range list {
+		cur, err := FromGoogleMoney(l.Money)
+		if l.Err != nil {
+			asserter.ErrorIs(err, l.Err)
+		} else if asserter.NoError(err) {
+			asserter.Equal(l.Exact, cur.FractionalTotal())
+		}
+
+		cur, err = FromGoogleMoneyRounded(l.Money, l.Mode)
+		if asserter.NoError(err) {
+			asserter.Equal(l.Rounded, cur.FractionalTotal())
+			asserter.Equal(l.Money.CurrencyCode, cur.Code)
+		}
+	}
+
+	invalid := []struct {
+		Money GoogleMoney
+		Err   error
+	}{
+		{Money: GoogleMoney{CurrencyCode: "INR", Units: 1, Nanos: -1}, Err: ErrInvalidGoogleMoney},
+		{Money: GoogleMoney{CurrencyCode: "INR", Units: -1, Nanos: 1}, Err: ErrInvalidGoogleMoney},
+		{Money: GoogleMoney{CurrencyCode: "INR", Nanos: 1000000000}, Err: ErrInvalidGoogleMoney},
+		{Money: GoogleMoney{CurrencyCode: "XYZ", Units: 1}, Err: ErrUnknownCurrency},
+	}
+
+	for _, l := range invalid {
+		_, err := FromGoogleMoney(l.Money)
+		asserter.ErrorIs(err, l.Err)
+
+		_, err = FromGoogleMoneyRounded(l.Money, RoundHalfUp)
+		asserter.ErrorIs(err, l.Err)
+	}
+
+	_, err := FromGoogleMoneyRounded(GoogleMoney{CurrencyCode: "INR", Nanos: 1}, RoundingMode(99))
+	asserter.ErrorIs(err, ErrInvalidRoundingMode)
+}
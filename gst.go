@@ -0,0 +1,185 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrInvalidGST is the error returned when the GST rates, supply type or residue rule are invalid
+var ErrInvalidGST = errors.New("invalid GST parameters provided")
+
+// SupplyType is the type of supply under Indian GST, which decides the components of the tax.
+type SupplyType int
+
+const (
+	// IntraState supply is taxed with CGST & SGST, each half of the GST rate
+	IntraState SupplyType = iota
+	// IntraUnionTerritory supply is taxed with CGST & UTGST, each half of the GST rate
+	IntraUnionTerritory
+	// InterState supply (including imports & exports) is taxed with IGST at the full GST rate
+	InterState
+)
+
+// GSTResidue is the rule for splitting GST into the central & state halves, when the total
+// GST has an odd number of paise.
+type GSTResidue int
+
+const (
+	// ResidueToCentral rounds the total GST, and adds the odd paisa to CGST
+	ResidueToCentral GSTResidue = iota
+	// ResidueToState rounds the total GST, and adds the odd paisa to SGST/UTGST
+	ResidueToState
+	// ResidueRoundEach rounds each half individually, and the total GST is the sum of the halves
+	ResidueRoundEach
+)
+
+func (gr GSTResidue) String() string {
+	switch gr {
+	case ResidueToCentral:
+		return "ToCentral"
+	case ResidueToState:
+		return "ToState"
+	case ResidueRoundEach:
+		return "RoundEach"
+	}
+
+	return fmt.Sprintf("GSTResidue(%d)", int(gr))
+}
+
+// GST computes Indian Goods & Services Tax on a taxable value, split into its components as per
+// the type of supply. The zero value is an intra-state supply at 0%.
+type GST struct {
+	// Rate is the GST rate slab, e.g. 18%
+	Rate Rate
+	// Cess is the compensation cess rate levied in addition to GST, e.g. 12%. Zero if not applicable.
+	Cess Rate
+	// Supply is the type of supply
+	Supply SupplyType
+	// Rounding is the rounding mode used to round the tax to paise
+	Rounding RoundingMode
+	// Residue is the rule for splitting an odd paisa between the central & state halves
+	Residue GSTResidue
+}
+
+// GSTBreakdown is the tax breakdown of GST, where the components are CGST & SGST/UTGST, or
+// IGST, followed by Cess if applicable.
+type GSTBreakdown struct {
+	TaxBreakdown
+	// Residue is the rule applied for splitting GST into halves
+	Residue GSTResidue
+	// ResidueUnits is the number of fractional units assigned by the residue rule, i.e. 1 if
+	// the total GST had an odd number of paise, otherwise 0
+	ResidueUnits int
+}
+
+// Compute computes GST on the taxable value.
+func (g GST) Compute(taxable *Currency) (*GSTBreakdown, error) {
+	if taxable.FUShare == 0 {
+		return nil, ErrInvalidFUS
+	}
+
+	if g.Rate.Rat().Sign() < 0 || g.Cess.Rat().Sign() < 0 {
+		return nil, fmt.Errorf("%w: rates must not be negative", ErrInvalidGST)
+	}
+
+	components, err := g.components(taxable)
+	if err != nil {
+		return nil, err
+	}
+
+	gb := &GSTBreakdown{Residue: g.Residue}
+
+	if !g.Cess.IsZero() {
+		cess, err := g.Cess.Apply(taxable, g.Rounding)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, TaxAmount{
+			TaxComponent: TaxComponent{Name: "Cess", Rate: g.Cess},
+			Amount:       *cess,
+		})
+	}
+
+	gb.Net = *taxable
+	gb.Tax = *taxable
+	gb.Tax.UpdateWithFractional(0)
+	for _, ta := range components {
+		gb.Tax.UpdateWithFractional(gb.Tax.FractionalTotal() + ta.Amount.FractionalTotal())
+	}
+
+	gb.Gross = *taxable
+	err = gb.Gross.UpdateWithFractionalE(taxable.FractionalTotal() + gb.Tax.FractionalTotal())
+	if err != nil {
+		return nil, err
+	}
+
+	gb.Components = components
+	if g.Supply != InterState && g.Residue != ResidueRoundEach {
+		gb.ResidueUnits = abs(components[0].Amount.FractionalTotal() - components[1].Amount.FractionalTotal())
+	}
+
+	return gb, nil
+}
+
+// components returns the GST components, i.e. CGST & SGST/UTGST, or IGST
+func (g GST) components(taxable *Currency) ([]TaxAmount, error) {
+	if g.Supply == InterState {
+		igst, err := g.Rate.Apply(taxable, g.Rounding)
+		if err != nil {
+			return nil, err
+		}
+
+		return []TaxAmount{{TaxComponent: TaxComponent{Name: "IGST", Rate: g.Rate}, Amount: *igst}}, nil
+	}
+
+	state := "SGST"
+	switch g.Supply {
+	case IntraState:
+	case IntraUnionTerritory:
+		state = "UTGST"
+	default:
+		return nil, fmt.Errorf("%w: supply type %d", ErrInvalidGST, int(g.Supply))
+	}
+
+	half := NewRate(g.Rate.Rat().Quo(g.Rate.Rat(), big.NewRat(2, 1)))
+	var central, local *Currency
+
+	switch g.Residue {
+	case ResidueRoundEach:
+		h, err := half.Apply(taxable, g.Rounding)
+		if err != nil {
+			return nil, err
+		}
+		central, local = h, h
+
+	case ResidueToCentral, ResidueToState:
+		total, err := g.Rate.Apply(taxable, g.Rounding)
+		if err != nil {
+			return nil, err
+		}
+
+		// the first share of the allocation gets the odd paisa
+		halves, _ := total.AllocateRatios(1, 1)
+		central, local = &halves[0], &halves[1]
+		if g.Residue == ResidueToState {
+			central, local = local, central
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: residue rule %s", ErrInvalidGST, g.Residue)
+	}
+
+	return []TaxAmount{
+		{TaxComponent: TaxComponent{Name: "CGST", Rate: half}, Amount: *central},
+		{TaxComponent: TaxComponent{Name: state, Rate: half}, Amount: *local},
+	}, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
@@ -0,0 +1,145 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGST(t *testing.T) {
+	tests := []struct {
+		name     string
+		taxable  int
+		gst      GST
+		amounts  []string
+		tax      string
+		gross    string
+		residual int
+	}{
+		{
+			name:    "intra state, even",
+			taxable: 100000,
+			gst:     GST{Rate: MustParseRate("18%")},
+			amounts: []string{"CGST 90.00", "SGST 90.00"},
+			tax:     "180.00",
+			gross:   "1180.00",
+		},
+		{
+			// 10.05 × 18% = 1.809, total 1.81
+			name:     "intra state, odd paisa to central",
+			taxable:  1005,
+			gst:      GST{Rate: MustParseRate("18%")},
+			amounts:  []string{"CGST 0.91", "SGST 0.90"},
+			tax:      "1.81",
+			gross:    "11.86",
+			residual: 1,
+		},
+		{
+			name:     "intra state, odd paisa to state",
+			taxable:  1005,
+			gst:      GST{Rate: MustParseRate("18%"), Residue: ResidueToState},
+			amounts:  []string{"CGST 0.90", "SGST 0.91"},
+			tax:      "1.81",
+			gross:    "11.86",
+			residual: 1,
+		},
+		{
+			// 10.05 × 9% = 0.9045, each 0.90
+			name:    "intra state, round each",
+			taxable: 1005,
+			gst:     GST{Rate: MustParseRate("18%"), Residue: ResidueRoundEach},
+			amounts: []string{"CGST 0.90", "SGST 0.90"},
+			tax:     "1.80",
+			gross:   "11.85",
+		},
+		{
+			name:    "union territory",
+			taxable: 20000,
+			gst:     GST{Rate: MustParseRate("5%"), Supply: IntraUnionTerritory},
+			amounts: []string{"CGST 5.00", "UTGST 5.00"},
+			tax:     "10.00",
+			gross:   "210.00",
+		},
+		{
+			name:    "inter state",
+			taxable: 1005,
+			gst:     GST{Rate: MustParseRate("18%"), Supply: InterState},
+			amounts: []string{"IGST 1.81"},
+			tax:     "1.81",
+			gross:   "11.86",
+		},
+		{
+			// 100.05 × 28% = 28.014, cess 100.05 × 12% = 12.006
+			name:     "with cess",
+			taxable:  10005,
+			gst:      GST{Rate: MustParseRate("28%"), Cess: MustParseRate("12%")},
+			amounts:  []string{"CGST 14.01", "SGST 14.00", "Cess 12.01"},
+			tax:      "40.02",
+			gross:    "140.07",
+			residual: 1,
+		},
+		{
+			name:     "credit note",
+			taxable:  -1005,
+			gst:      GST{Rate: MustParseRate("18%")},
+			amounts:  []string{"CGST -0.91", "SGST -0.90"},
+			tax:      "-1.81",
+			gross:    "-11.86",
+			residual: 1,
+		},
+		{
+			name:    "nil rated",
+			taxable: 1005,
+			gst:     GST{},
+			amounts: []string{"CGST 0.00", "SGST 0.00"},
+			tax:     "0.00",
+			gross:   "10.05",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taxable, err := NewFractional(tt.taxable, "INR", "₹", "paise", 100)
+			require.NoError(t, err)
+
+			gb, err := tt.gst.Compute(taxable)
+			require.NoError(t, err)
+			assert.Equal(t, tt.amounts, taxAmounts(&gb.TaxBreakdown))
+			assert.Equal(t, tt.tax, gb.Tax.StringWithoutSymbols())
+			assert.Equal(t, tt.gross, gb.Gross.StringWithoutSymbols())
+			assert.Equal(t, tt.taxable, gb.Net.FractionalTotal())
+			assert.Equal(t, tt.residual, gb.ResidueUnits)
+			assert.Equal(t, tt.gst.Residue, gb.Residue)
+		})
+	}
+}
+
+func TestGSTInvalid(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	taxable, err := New(100, 0, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+
+	_, err = GST{Rate: MustParseRate("-5%")}.Compute(taxable)
+	asserter.ErrorIs(err, ErrInvalidGST)
+
+	_, err = GST{Rate: MustParseRate("5%"), Cess: MustParseRate("-1%")}.Compute(taxable)
+	asserter.ErrorIs(err, ErrInvalidGST)
+
+	_, err = GST{Rate: MustParseRate("5%"), Supply: SupplyType(9)}.Compute(taxable)
+	asserter.ErrorIs(err, ErrInvalidGST)
+
+	_, err = GST{Rate: MustParseRate("5%"), Residue: GSTResidue(9)}.Compute(taxable)
+	asserter.ErrorIs(err, ErrInvalidGST)
+	asserter.Contains(err.Error(), "GSTResidue(9)")
+
+	_, err = GST{Rate: MustParseRate("5%"), Rounding: RoundingMode(9)}.Compute(taxable)
+	asserter.ErrorIs(err, ErrInvalidRoundingMode)
+
+	_, err = GST{Rate: MustParseRate("5%")}.Compute(&Currency{})
+	asserter.ErrorIs(err, ErrInvalidFUS)
+
+	asserter.Equal("RoundEach", ResidueRoundEach.String())
+}
@@ -0,0 +1,274 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// ErrInvalidInvoice is the error returned when an invoice has no lines, or a line is invalid
+var ErrInvalidInvoice = errors.New("invalid invoice provided")
+
+// RoundingPolicy decides at which level the tax of an invoice is rounded.
+type RoundingPolicy int
+
+const (
+	// RoundPerLine rounds the tax of every line, and the tax of the invoice is their sum
+	RoundPerLine RoundingPolicy = iota
+	// RoundPerDocument sums the exact tax of the lines per tax rate, and rounds each sum once. The
+	// rounded tax is allocated back to the lines in proportion to their exact tax.
+	RoundPerDocument
+)
+
+func (rp RoundingPolicy) String() string {
+	switch rp {
+	case RoundPerLine:
+		return "PerLine"
+	case RoundPerDocument:
+		return "PerDocument"
+	}
+
+	return fmt.Sprintf("RoundingPolicy(%d)", int(rp))
+}
+
+// InvoiceLine is a line item of an invoice.
+type InvoiceLine struct {
+	Description string
+	// UnitPrice is the net price of 1 unit
+	UnitPrice Currency
+	// Quantity is the number of units as a decimal string, e.g. "2" or "1.250"
+	Quantity string
+	// Discount is the discount on the line amount, e.g. 10%
+	Discount Rate
+	// TaxRate is the rate of tax levied on the discounted line amount, e.g. 18%
+	TaxRate Rate
+}
+
+// InvoiceLineTotal is the computed amounts of an invoice line.
+type InvoiceLineTotal struct {
+	InvoiceLine
+	// Amount is UnitPrice × Quantity
+	Amount Currency
+	// Discount is the discount on Amount
+	Discount Currency
+	// Net is Amount - Discount, on which the tax is levied
+	Net Currency
+	// Tax is the tax of the line as per the rounding policy
+	Tax Currency
+	// Gross is Net + Tax
+	Gross Currency
+}
+
+// InvoiceTotals is the computed amounts of an invoice.
+type InvoiceTotals struct {
+	Lines []InvoiceLineTotal
+	// Subtotal is the sum of the net amounts of the lines
+	Subtotal Currency
+	// Discount is the sum of the discounts of the lines
+	Discount Currency
+	// Tax is the tax of the invoice as per the rounding policy
+	Tax Currency
+	// Total is Subtotal + Tax
+	Total Currency
+	// RoundingDifference is the tax rounded per document minus the tax rounded per line,
+	// irrespective of the policy used
+	RoundingDifference Currency
+}
+
+// Invoice computes the totals of invoice lines, all of which must be in the same currency.
+type Invoice struct {
+	Lines []InvoiceLine
+	// Policy is the level at which the tax is rounded
+	Policy RoundingPolicy
+	// Rounding is the rounding mode used for all the amounts
+	Rounding RoundingMode
+}
+
+// Compute computes the amounts of every line & the totals of the invoice. Line amounts &
+// discounts are always rounded per line, and the tax as per the rounding policy.
+func (inv Invoice) Compute() (*InvoiceTotals, error) {
+	if len(inv.Lines) == 0 {
+		return nil, fmt.Errorf("%w: no lines", ErrInvalidInvoice)
+	}
+
+	if inv.Policy != RoundPerLine && inv.Policy != RoundPerDocument {
+		return nil, fmt.Errorf("%w: rounding policy %s", ErrInvalidInvoice, inv.Policy)
+	}
+
+	first := &inv.Lines[0].UnitPrice
+	lines := make([]InvoiceLineTotal, 0, len(inv.Lines))
+	for i := range inv.Lines {
+		err := first.match("Invoice", &inv.Lines[i].UnitPrice)
+		if err != nil {
+			return nil, err
+		}
+
+		lt, err := inv.computeLine(inv.Lines[i])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		lines = append(lines, lt)
+	}
+
+	perLine, err := inv.lineTaxes(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	perDocument, err := inv.documentTaxes(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	taxes := perLine
+	if inv.Policy == RoundPerDocument {
+		taxes = perDocument
+	}
+
+	it := &InvoiceTotals{Lines: lines}
+	it.Subtotal, it.Discount, it.Tax, it.Total, it.RoundingDifference = *first, *first, *first, *first, *first
+
+	var subtotal, discount, tax, diff int
+	for i := range lines {
+		lines[i].Tax = lines[i].Net
+		lines[i].Tax.UpdateWithFractional(taxes[i])
+		lines[i].Gross = lines[i].Net
+		lines[i].Gross.UpdateWithFractional(lines[i].Net.FractionalTotal() + taxes[i])
+
+		subtotal += lines[i].Net.FractionalTotal()
+		discount += lines[i].Discount.FractionalTotal()
+		tax += taxes[i]
+		diff += perDocument[i] - perLine[i]
+	}
+
+	it.Subtotal.UpdateWithFractional(subtotal)
+	it.Discount.UpdateWithFractional(discount)
+	it.Tax.UpdateWithFractional(tax)
+	it.Total.UpdateWithFractional(subtotal + tax)
+	it.RoundingDifference.UpdateWithFractional(diff)
+
+	return it, nil
+}
+
+// computeLine computes the amount, discount & net amount of a line
+func (inv Invoice) computeLine(line InvoiceLine) (InvoiceLineTotal, error) {
+	lt := InvoiceLineTotal{InvoiceLine: line}
+
+	qty, err := parseDecimal(line.Quantity)
+	if err != nil {
+		return lt, fmt.Errorf("%w: quantity %q", ErrInvalidInvoice, line.Quantity)
+	}
+
+	amount, err := line.UnitPrice.scaled(qty, inv.Rounding)
+	if err != nil {
+		return lt, err
+	}
+
+	discount, err := line.Discount.Apply(amount, inv.Rounding)
+	if err != nil {
+		return lt, err
+	}
+
+	lt.Amount = *amount
+	lt.Discount = *discount
+	lt.Net = *amount
+	err = lt.Net.UpdateWithFractionalE(amount.FractionalTotal() - discount.FractionalTotal())
+	if err != nil {
+		return lt, err
+	}
+
+	return lt, nil
+}
+
+// lineTaxes returns the tax of every line, rounded per line
+func (inv Invoice) lineTaxes(lines []InvoiceLineTotal) ([]int, error) {
+	taxes := make([]int, 0, len(lines))
+	for _, lt := range lines {
+		tax, err := lt.TaxRate.Apply(&lt.Net, inv.Rounding)
+		if err != nil {
+			return nil, err
+		}
+		taxes = append(taxes, tax.FractionalTotal())
+	}
+
+	return taxes, nil
+}
+
+// documentTaxes returns the tax of every line, where the exact taxes are summed & rounded per
+// tax rate, and allocated back to the lines. Lines with a negative net amount, e.g. returns, get
+// a negative tax.
+func (inv Invoice) documentTaxes(lines []InvoiceLineTotal) ([]int, error) {
+	groups := make(map[string][]int)
+	order := []string{}
+	for i, lt := range lines {
+		key := lt.TaxRate.Rat().RatString()
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	taxes := make([]int, len(lines))
+	for _, key := range order {
+		idxs := groups[key]
+		exact := make([]*big.Rat, 0, len(idxs))
+		sum := new(big.Rat)
+		for _, i := range idxs {
+			t := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(lines[i].Net.FractionalTotal())), lines[i].TaxRate.Rat())
+			exact = append(exact, t)
+			sum.Add(sum, t)
+		}
+
+		total, err := roundFractional(sum, inv.Rounding)
+		if err != nil {
+			return nil, err
+		}
+
+		allocated := allocateResidue(exact, total)
+		for j, i := range idxs {
+			taxes[i] = allocated[j]
+		}
+	}
+
+	return taxes, nil
+}
+
+// allocateResidue allocates the rounded total of the exact amounts back to them. Every amount
+// gets its integer part (truncated towards zero), and the rest of the total is allocated 1 unit
+// at a time to the amounts with the largest fractional parts of the same sign.
+func allocateResidue(exact []*big.Rat, total int) []int {
+	shares := make([]int, len(exact))
+	fractions := make([]*big.Rat, len(exact))
+	residue := total
+	for i, r := range exact {
+		q := new(big.Int).Quo(r.Num(), r.Denom())
+		shares[i] = int(q.Int64())
+		fractions[i] = new(big.Rat).Sub(r, new(big.Rat).SetInt(q))
+		residue -= shares[i]
+	}
+
+	if residue == 0 || len(exact) == 0 {
+		return shares
+	}
+
+	unit := 1
+	if residue < 0 {
+		unit, residue = -1, -residue
+	}
+
+	order := make([]int, len(exact))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		cmp := fractions[order[i]].Cmp(fractions[order[j]])
+		return cmp*unit > 0
+	})
+
+	for i := 0; i < residue; i++ {
+		shares[order[i%len(order)]] += unit
+	}
+
+	return shares
+}
@@ -0,0 +1,164 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func invoiceLine(t *testing.T, ftotal int, qty string, discount, tax string) InvoiceLine {
+	price, err := NewFractional(ftotal, "EUR", "€", "cent", 100)
+	require.NoError(t, err)
+
+	line := InvoiceLine{UnitPrice: *price, Quantity: qty, TaxRate: MustParseRate(tax)}
+	if discount != "" {
+		line.Discount = MustParseRate(discount)
+	}
+
+	return line
+}
+
+func TestInvoicePolicies(t *testing.T) {
+	lines := []InvoiceLine{
+		invoiceLine(t, 105, "1", "", "10%"),
+		invoiceLine(t, 105, "1", "", "10%"),
+		invoiceLine(t, 105, "1", "", "10%"),
+	}
+
+	tests := []struct {
+		policy   RoundingPolicy
+		lineTax  []string
+		tax      string
+		total    string
+		roundDif string
+	}{
+		{policy: RoundPerLine, lineTax: []string{"0.11", "0.11", "0.11"}, tax: "0.33", total: "3.48", roundDif: "-0.01"},
+		// 3 × 0.105 = 0.315 rounded once
+		{policy: RoundPerDocument, lineTax: []string{"0.11", "0.11", "0.10"}, tax: "0.32", total: "3.47", roundDif: "-0.01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			it, err := Invoice{Lines: lines, Policy: tt.policy, Rounding: RoundHalfUp}.Compute()
+			require.NoError(t, err)
+
+			lineTax := []string{}
+			for _, lt := range it.Lines {
+				lineTax = append(lineTax, lt.Tax.StringWithoutSymbols())
+				assert.Equal(t, lt.Gross.FractionalTotal(), lt.Net.FractionalTotal()+lt.Tax.FractionalTotal())
+			}
+			assert.Equal(t, tt.lineTax, lineTax)
+			assert.Equal(t, "3.15", it.Subtotal.StringWithoutSymbols())
+			assert.Equal(t, tt.tax, it.Tax.StringWithoutSymbols())
+			assert.Equal(t, tt.total, it.Total.StringWithoutSymbols())
+			assert.Equal(t, tt.roundDif, it.RoundingDifference.StringWithoutSymbols())
+			assert.Equal(t, "EUR", it.Total.Code)
+		})
+	}
+}
+
+func TestInvoiceLines(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	inv := Invoice{
+		Lines: []InvoiceLine{
+			// 2.49 × 1.5 = 3.735, discount 0.374, tax on 3.37 = 0.6066
+			invoiceLine(t, 249, "1.5", "10%", "18%"),
+			// 19.99 × 3 = 59.97, tax 10.7946
+			invoiceLine(t, 1999, "3", "", "18%"),
+			// 4.99 × 0.333 = 1.66167, tax on 1.66 = 0.083
+			invoiceLine(t, 499, "0.333", "", "5%"),
+		},
+		Policy:   RoundPerDocument,
+		Rounding: RoundHalfEven,
+	}
+
+	it, err := inv.Compute()
+	requirer.NoError(err)
+
+	l := it.Lines[0]
+	asserter.Equal("3.74", l.Amount.StringWithoutSymbols())
+	asserter.Equal("0.37", l.Discount.StringWithoutSymbols())
+	asserter.Equal("3.37", l.Net.StringWithoutSymbols())
+
+	asserter.Equal("65.00", it.Subtotal.StringWithoutSymbols())
+	asserter.Equal("0.37", it.Discount.StringWithoutSymbols())
+	// 18%: 0.6066 + 10.7946 = 11.4012, 5%: 0.083
+	asserter.Equal("11.48", it.Tax.StringWithoutSymbols())
+	asserter.Equal("76.48", it.Total.StringWithoutSymbols())
+	asserter.Equal("0.61", it.Lines[0].Tax.StringWithoutSymbols())
+	asserter.Equal("10.79", it.Lines[1].Tax.StringWithoutSymbols())
+	asserter.Equal("0.08", it.Lines[2].Tax.StringWithoutSymbols())
+	asserter.Equal("0.00", it.RoundingDifference.StringWithoutSymbols())
+
+	// a credit note
+	refund := Invoice{Lines: []InvoiceLine{
+		invoiceLine(t, -105, "1", "", "10%"),
+		invoiceLine(t, -105, "1", "", "10%"),
+		invoiceLine(t, -105, "1", "", "10%"),
+	}, Policy: RoundPerDocument}
+	it, err = refund.Compute()
+	requirer.NoError(err)
+	asserter.Equal("-0.32", it.Tax.StringWithoutSymbols())
+	asserter.Equal("-0.11", it.Lines[0].Tax.StringWithoutSymbols())
+	asserter.Equal("-0.10", it.Lines[2].Tax.StringWithoutSymbols())
+	asserter.Equal("0.01", it.RoundingDifference.StringWithoutSymbols())
+
+	// a return line on an invoice
+	mixed := Invoice{Lines: []InvoiceLine{
+		invoiceLine(t, 10000, "1", "", "10%"),
+		invoiceLine(t, -5000, "1", "", "10%"),
+	}, Policy: RoundPerDocument}
+	it, err = mixed.Compute()
+	requirer.NoError(err)
+	asserter.Equal("10.00", it.Lines[0].Tax.StringWithoutSymbols())
+	asserter.Equal("-5.00", it.Lines[1].Tax.StringWithoutSymbols())
+	asserter.Equal("110.00", it.Lines[0].Gross.StringWithoutSymbols())
+	asserter.Equal("-55.00", it.Lines[1].Gross.StringWithoutSymbols())
+	asserter.Equal("5.00", it.Tax.StringWithoutSymbols())
+	asserter.Equal("55.00", it.Total.StringWithoutSymbols())
+
+	// 0.105 + 0.105 - 0.025 = 0.185 rounded once, the odd cent goes to a sale line
+	mixed.Lines = []InvoiceLine{
+		invoiceLine(t, 105, "1", "", "10%"),
+		invoiceLine(t, 105, "1", "", "10%"),
+		invoiceLine(t, -25, "1", "", "10%"),
+	}
+	it, err = mixed.Compute()
+	requirer.NoError(err)
+	asserter.Equal("0.19", it.Tax.StringWithoutSymbols())
+	asserter.Equal([]string{"0.11", "0.10", "-0.02"}, []string{
+		it.Lines[0].Tax.StringWithoutSymbols(),
+		it.Lines[1].Tax.StringWithoutSymbols(),
+		it.Lines[2].Tax.StringWithoutSymbols(),
+	})
+}
+
+func TestInvoiceInvalid(t *testing.T) {
+	asserter := assert.New(t)
+
+	_, err := Invoice{}.Compute()
+	asserter.ErrorIs(err, ErrInvalidInvoice)
+
+	_, err = Invoice{Lines: []InvoiceLine{invoiceLine(t, 100, "one", "", "10%")}}.Compute()
+	asserter.ErrorIs(err, ErrInvalidInvoice)
+	asserter.Contains(err.Error(), "line 1")
+
+	_, err = Invoice{Lines: []InvoiceLine{invoiceLine(t, 100, "1", "", "10%")}, Policy: RoundingPolicy(5)}.Compute()
+	asserter.ErrorIs(err, ErrInvalidInvoice)
+
+	usd, err := New(1, 0, "USD", "$", "cent", 100)
+	require.NoError(t, err)
+	_, err = Invoice{Lines: []InvoiceLine{
+		invoiceLine(t, 100, "1", "", "10%"),
+		{UnitPrice: *usd, Quantity: "1"},
+	}}.Compute()
+	me := &MismatchError{}
+	asserter.ErrorAs(err, &me)
+	asserter.Equal("Invoice", me.Op)
+
+	_, err = Invoice{Lines: []InvoiceLine{invoiceLine(t, 100, "1", "", "10%")}, Rounding: RoundingMode(42)}.Compute()
+	asserter.ErrorIs(err, ErrInvalidRoundingMode)
+}
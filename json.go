@@ -0,0 +1,223 @@
+package currency
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONFormat is the wire format used when marshalling a currency to JSON.
+type JSONFormat int
+
+const (
+	// JSONDecimal encodes the amount as a decimal string, e.g. {"amount":"12.50","currency":"USD"}
+	JSONDecimal JSONFormat = iota
+	// JSONMinorUnits encodes the amount as an integer of fractional units, e.g. {"amount":1250,"currency":"USD"}
+	JSONMinorUnits
+	// JSONString encodes the currency as a compact string, e.g. "USD 12.50"
+	JSONString
+)
+
+// DefaultJSONFormat is the format used by MarshalJSON.
+var DefaultJSONFormat = JSONDecimal
+
+// jsonDecimal is the wire representation of JSONDecimal & JSONMinorUnits. The meta data is
+// included only if the currency is not in the registry with the same meta data, so that it can
+// be unmarshalled without the registry.
+type jsonDecimal struct {
+	Amount       json.RawMessage `json:"amount"`
+	Currency     string          `json:"currency"`
+	Symbol       string          `json:"symbol,omitempty"`
+	FUName       string          `json:"fuName,omitempty"`
+	FUShare      uint            `json:"fuShare,omitempty"`
+	PrefixSymbol bool            `json:"alwaysAddPrefix,omitempty"`
+	SuffixSymbol bool            `json:"alwaysAddSuffix,omitempty"`
+}
+
+// jsonObject is any of the object representations of a currency, i.e. jsonDecimal or the legacy
+// representation of v2, e.g. {"code":"INR","main":10,"fractional":50,"fuShare":100}
+type jsonObject struct {
+	jsonDecimal
+	Code       string `json:"code"`
+	Main       int    `json:"main"`
+	Fractional int    `json:"fractional"`
+}
+
+// newJSONDecimal returns the wire representation of c with the amount, including the meta data
+// if required
+func newJSONDecimal(c Currency, amount json.RawMessage) jsonDecimal {
+	jd := jsonDecimal{
+		Amount:       amount,
+		Currency:     c.Code,
+		PrefixSymbol: c.PrefixSymbol,
+		SuffixSymbol: c.SuffixSymbol,
+	}
+
+	m, err := Lookup(c.Code)
+	if err != nil || m.Symbol != c.Symbol || m.FUName != c.FUName || m.FUShare != c.FUShare {
+		jd.Symbol, jd.FUName, jd.FUShare = c.Symbol, c.FUName, c.FUShare
+	}
+
+	return jd
+}
+
+// meta returns the meta data of the currency code, same as metaFor, overridden by the meta data
+// in the object if any
+func (jd jsonDecimal) meta(c *Currency, code string) (Meta, error) {
+	m, err := c.metaFor(code)
+	if jd.FUShare == 0 {
+		if err != nil {
+			return Meta{}, fmt.Errorf("%w: %q", err, code)
+		}
+
+		return m, nil
+	}
+
+	if err != nil {
+		m = Meta{Code: code}
+	}
+
+	m.FUShare = jd.FUShare
+	if jd.Symbol != "" {
+		m.Symbol = jd.Symbol
+	}
+	if jd.FUName != "" {
+		m.FUName = jd.FUName
+	}
+
+	return m, nil
+}
+
+// MarshalJSON marshals the currency in DefaultJSONFormat. The zero value is marshalled as null.
+func (c Currency) MarshalJSON() ([]byte, error) {
+	return c.MarshalJSONFormat(DefaultJSONFormat)
+}
+
+// MarshalJSONFormat marshals the currency in the given format. The zero value is marshalled as null.
+func (c Currency) MarshalJSONFormat(f JSONFormat) ([]byte, error) {
+	if c.isBlank() {
+		return []byte("null"), nil
+	}
+
+	if c.FUShare == 0 {
+		return nil, ErrInvalidFUS
+	}
+
+	switch f {
+	case JSONDecimal:
+		{
+			amount, _ := json.Marshal(c.decimalString())
+			return json.Marshal(newJSONDecimal(c, amount))
+		}
+	case JSONMinorUnits:
+		{
+			amount, _ := json.Marshal(c.FractionalTotal())
+			return json.Marshal(newJSONDecimal(c, amount))
+		}
+	case JSONString:
+		{
+			text, _ := c.MarshalText()
+			return json.Marshal(string(text))
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported JSON format %d", f)
+}
+
+// UnmarshalJSON unmarshals any of the JSON formats into c, as well as the legacy object
+// representation of v2, e.g. {"code":"INR","main":10,"fractional":50,"fuShare":100}. The meta
+// data of the currency is taken from the JSON if included, otherwise from c if it already has
+// the same code, otherwise from the registry.
+func (c *Currency) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		str := ""
+		err := json.Unmarshal(data, &str)
+		if err != nil {
+			return err
+		}
+
+		return c.UnmarshalText([]byte(str))
+	}
+
+	obj := jsonObject{}
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return err
+	}
+
+	jd := obj.jsonDecimal
+	if jd.Currency == "" && obj.Code != "" {
+		return c.unmarshalLegacyJSON(obj)
+	}
+
+	m, err := jd.meta(c, jd.Currency)
+	if err != nil {
+		return err
+	}
+
+	if len(jd.Amount) > 0 && jd.Amount[0] == '"' {
+		amount := ""
+		err = json.Unmarshal(jd.Amount, &amount)
+		if err != nil {
+			return err
+		}
+
+		err = c.setDecimal(m, amount)
+	} else {
+		ftotal := 0
+		err = json.Unmarshal(jd.Amount, &ftotal)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidCurrency, string(jd.Amount))
+		}
+
+		err = c.setFractional(m, ftotal)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	c.PrefixSymbol = c.PrefixSymbol || jd.PrefixSymbol
+	c.SuffixSymbol = c.SuffixSymbol || jd.SuffixSymbol
+
+	return nil
+}
+
+// unmarshalLegacyJSON updates c with the legacy object representation of v2
+func (c *Currency) unmarshalLegacyJSON(obj jsonObject) error {
+	m, err := obj.meta(c, obj.Code)
+	if err != nil {
+		return err
+	}
+
+	nc, err := m.New(obj.Main, obj.Fractional)
+	if err != nil {
+		return err
+	}
+
+	nc.PrefixSymbol = c.PrefixSymbol || obj.PrefixSymbol
+	nc.SuffixSymbol = c.SuffixSymbol || obj.SuffixSymbol
+	*c = *nc
+
+	return nil
+}
+
+// setDecimal updates c with the meta data m and the exact value of the decimal string amount
+func (c *Currency) setDecimal(m Meta, amount string) error {
+	r, err := parseDecimal(amount)
+	if err != nil {
+		return err
+	}
+
+	ftotal, err := exactFractional(r, m.FUShare)
+	if err != nil {
+		return err
+	}
+
+	return c.setFractional(m, ftotal)
+}
@@ -0,0 +1,193 @@
+package currency
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	usd, err := New(12, 50, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	neg, err := NewFractional(-5, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	jpy, err := New(1234, 0, "JPY", "¥", "", 1)
+	requirer.NoError(err)
+
+	kwd, err := New(1, 5, "KWD", "د.ك", "fils", 1000)
+	requirer.NoError(err)
+
+	zero, err := New(0, 0, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	list := []struct {
+		Currency *Currency
+		Format   JSONFormat
+		Expected string
+	}{
+		{Currency: usd, Format: JSONDecimal, Expected: `{"amount":"12.50","currency":"USD"}`},
+		{Currency: usd, Format: JSONMinorUnits, Expected: `{"amount":1250,"currency":"USD"}`},
+		{Currency: usd, Format: JSONString, Expected: `"USD 12.50"`},
+		{Currency: neg, Format: JSONDecimal, Expected: `{"amount":"-0.05","currency":"USD"}`},
+		{Currency: neg, Format: JSONMinorUnits, Expected: `{"amount":-5,"currency":"USD"}`},
+		{Currency: jpy, Format: JSONDecimal, Expected: `{"amount":"1234","currency":"JPY"}`},
+		{Currency: kwd, Format: JSONString, Expected: `"KWD 1.005"`},
+		{Currency: zero, Format: JSONDecimal, Expected: `{"amount":"0.00","currency":"USD"}`},
+		{Currency: &Currency{}, Format: JSONDecimal, Expected: `null`},
+	}
+
+	for _, l := range list {
+		got, err := l.Currency.MarshalJSONFormat(l.Format)
+		requirer.NoError(err)
+		asserter.Equal(l.Expected, string(got))
+
+		decoded := Currency{}
+		requirer.NoError(json.Unmarshal(got, &decoded))
+		asserter.Equal(l.Currency.FractionalTotal(), decoded.FractionalTotal(), l.Expected)
+		asserter.Equal(l.Currency.String(), decoded.String(), l.Expected)
+	}
+
+	_, err = usd.MarshalJSONFormat(JSONFormat(99))
+	asserter.Error(err)
+
+	_, err = json.Marshal(Currency{Code: "USD", Main: 1})
+	asserter.ErrorIs(err, ErrInvalidFUS)
+}
+
+func TestMarshalJSONDefault(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	usd, err := New(12, 50, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	payload := struct {
+		Price  Currency  `json:"price"`
+		Refund *Currency `json:"refund"`
+	}{
+		Price:  *usd,
+		Refund: usd,
+	}
+
+	got, err := json.Marshal(payload)
+	requirer.NoError(err)
+	asserter.Equal(`{"price":{"amount":"12.50","currency":"USD"},"refund":{"amount":"12.50","currency":"USD"}}`, string(got))
+
+	DefaultJSONFormat = JSONString
+	defer func() {
+		DefaultJSONFormat = JSONDecimal
+	}()
+
+	got, err = json.Marshal(usd)
+	requirer.NoError(err)
+	asserter.Equal(`"USD 12.50"`, string(got))
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	cur := Currency{}
+	requirer.NoError(json.Unmarshal([]byte(`{"amount":"1.5","currency":"INR"}`), &cur))
+	asserter.Equal("1.50", cur.StringWithoutSymbols())
+	asserter.Equal("₹", cur.Symbol)
+
+	pct := cur.Percent(33)
+	requirer.NotNil(pct)
+	asserter.Equal(50, pct.FractionalTotal())
+
+	custom, err := New(0, 0, "XTS", "T", "tick", 1000)
+	requirer.NoError(err)
+	custom.PrefixSymbol = true
+	requirer.NoError(json.Unmarshal([]byte(`"XTS 2.125"`), custom))
+	asserter.Equal("T2.125", custom.String())
+
+	requirer.NoError(json.Unmarshal([]byte(`null`), custom))
+	asserter.Equal("T2.125", custom.String())
+
+	list := []struct {
+		Input string
+		Err   error
+	}{
+		{Input: `{"amount":"1.505","currency":"INR"}`, Err: ErrPrecisionLoss},
+		{Input: `{"amount":1.5,"currency":"INR"}`, Err: ErrInvalidCurrency},
+		{Input: `{"amount":"abc","currency":"INR"}`, Err: ErrInvalidCurrency},
+		{Input: `{"amount":"0x10","currency":"USD"}`, Err: ErrInvalidCurrency},
+		{Input: `{"code":"XYZ","main":1}`, Err: ErrUnknownCurrency},
+		{Input: `{"amount":"1.50","currency":"XYZ"}`, Err: ErrUnknownCurrency},
+		{Input: `"INR"`, Err: ErrInvalidCurrency},
+		{Input: `"XYZ 1.50"`, Err: ErrUnknownCurrency},
+		{Input: `"USD 1e3"`, Err: ErrInvalidCurrency},
+	}
+
+	for _, l := range list {
+		cur := Currency{}
+		asserter.ErrorIs(json.Unmarshal([]byte(l.Input), &cur), l.Err, l.Input)
+	}
+}
+
+func TestUnmarshalJSONLegacy(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	cur := Currency{}
+	requirer.NoError(json.Unmarshal([]byte(`{"code":"INR","main":10,"fractional":50,"fuShare":100}`), &cur))
+	asserter.Equal("10.50", cur.StringWithoutSymbols())
+	asserter.Equal("₹", cur.Symbol)
+	asserter.Equal("paise", cur.FUName)
+
+	custom := Currency{}
+	requirer.NoError(json.Unmarshal([]byte(
+		`{"code":"XTS","symbol":"T","main":2,"fractional":1250,"fuName":"tick","fuShare":1000,"alwaysAddPrefix":true}`,
+	), &custom))
+	asserter.Equal("T3.250", custom.String())
+	asserter.Equal("tick", custom.FUName)
+	asserter.True(custom.PrefixSymbol)
+}
+
+func TestMarshalJSONMeta(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	// not in the registry, the meta data is included
+	custom, err := New(2, 125, "XTS", "T", "tick", 1000)
+	requirer.NoError(err)
+	custom.PrefixSymbol = true
+
+	got, err := json.Marshal(custom)
+	requirer.NoError(err)
+	asserter.Equal(
+		`{"amount":"2.125","currency":"XTS","symbol":"T","fuName":"tick","fuShare":1000,"alwaysAddPrefix":true}`,
+		string(got),
+	)
+
+	decoded := Currency{}
+	requirer.NoError(json.Unmarshal(got, &decoded))
+	asserter.Equal(*custom, decoded)
+
+	got, err = custom.MarshalJSONFormat(JSONMinorUnits)
+	requirer.NoError(err)
+	decoded = Currency{}
+	requirer.NoError(json.Unmarshal(got, &decoded))
+	asserter.Equal(*custom, decoded)
+
+	// the same as the registry, only the symbol preferences are included
+	inr, err := New(10, 50, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+	inr.SuffixSymbol = true
+
+	got, err = json.Marshal(inr)
+	requirer.NoError(err)
+	asserter.Equal(`{"amount":"10.50","currency":"INR","alwaysAddSuffix":true}`, string(got))
+
+	decoded = Currency{}
+	requirer.NoError(json.Unmarshal(got, &decoded))
+	asserter.Equal(*inr, decoded)
+}
@@ -0,0 +1,229 @@
+package currency
+
+import (
+	"encoding/json"
+)
+
+// Unit is a type representing a currency in the typed API, e.g. USD. Its Meta method must work
+// on the zero value of the type.
+//
+// Custom units can be defined for any currency, e.g.
+//
+//	type XYZ struct{}
+//
+//	func (XYZ) Meta() currency.Meta {
+//		return currency.Meta{Code: "XYZ", Symbol: "x", FUName: "xcent", FUShare: 100}
+//	}
+//
+// The fractional share of the meta data must be at least 1.
+type Unit interface {
+	Meta() Meta
+}
+
+// Money is an amount of the currency U, e.g. Money[USD]. Since the currency is part of the type,
+// mixing currencies like adding Money[USD] to Money[EUR] fails to compile. The zero value is 0
+// of the currency U.
+//
+// Money is an immutable value, all operations return a new value. Use Currency & MoneyFrom
+// to convert to & from Currency at boundaries like JSON and databases.
+type Money[U Unit] struct {
+	ftotal int
+}
+
+// NewMoney returns a new amount of the currency U, given the main & fractional values, the
+// same as New. e.g. NewMoney[USD](10, 50) is $10.50
+func NewMoney[U Unit](main int, fractional int) (Money[U], error) {
+	c, err := unitMeta[U]().New(main, fractional)
+	if err != nil {
+		return Money[U]{}, err
+	}
+
+	return Money[U]{ftotal: c.FractionalTotal()}, nil
+}
+
+// MoneyFromFractional returns a new amount of the currency U, given the total value in
+// fractional unit.
+func MoneyFromFractional[U Unit](ftotal int) Money[U] {
+	return Money[U]{ftotal: ftotal}
+}
+
+// MoneyFrom returns the amount of c as Money[U]. c must have the same code & fractional share
+// as U, otherwise a *MismatchError is returned.
+func MoneyFrom[U Unit](c *Currency) (Money[U], error) {
+	m := unitMeta[U]()
+	if c.Code != m.Code || c.FUShare != m.FUShare {
+		return Money[U]{}, &MismatchError{
+			Op:           "MoneyFrom",
+			Code:         m.Code,
+			FUShare:      m.FUShare,
+			OtherCode:    c.Code,
+			OtherFUShare: c.FUShare,
+		}
+	}
+
+	return Money[U]{ftotal: c.FractionalTotal()}, nil
+}
+
+// unitMeta returns the meta data of the currency U
+func unitMeta[U Unit]() Meta {
+	var u U
+	return u.Meta()
+}
+
+// Meta returns the meta data of the currency of m.
+func (m Money[U]) Meta() Meta {
+	return unitMeta[U]()
+}
+
+// FractionalTotal returns the total value of m in its fractional unit.
+func (m Money[U]) FractionalTotal() int {
+	return m.ftotal
+}
+
+// IsZero returns true if the amount is 0.
+func (m Money[U]) IsZero() bool {
+	return m.ftotal == 0
+}
+
+// Add returns m + o.
+func (m Money[U]) Add(o Money[U]) Money[U] {
+	return Money[U]{ftotal: m.ftotal + o.ftotal}
+}
+
+// Subtract returns m - o.
+func (m Money[U]) Subtract(o Money[U]) Money[U] {
+	return Money[U]{ftotal: m.ftotal - o.ftotal}
+}
+
+// Multiply returns m × by.
+func (m Money[U]) Multiply(by int) Money[U] {
+	return Money[U]{ftotal: m.ftotal * by}
+}
+
+// Negate returns -m.
+func (m Money[U]) Negate() Money[U] {
+	return Money[U]{ftotal: -m.ftotal}
+}
+
+// Cmp compares m & o, and returns -1 if m < o, 0 if m == o, and +1 if m > o.
+func (m Money[U]) Cmp(o Money[U]) int {
+	switch {
+	case m.ftotal < o.ftotal:
+		return -1
+	case m.ftotal > o.ftotal:
+		return 1
+	}
+
+	return 0
+}
+
+// Currency returns m as a new instance of Currency.
+func (m Money[U]) Currency() *Currency {
+	meta := m.Meta()
+	c := &Currency{Code: meta.Code, Symbol: meta.Symbol, FUName: meta.FUName, FUShare: meta.FUShare}
+	c.UpdateWithFractional(m.ftotal)
+	return c
+}
+
+// String returns the amount with its symbol, the same as Currency.String.
+func (m Money[U]) String() string {
+	return m.Currency().String()
+}
+
+// MarshalJSON encodes m the same as Currency, in DefaultJSONFormat.
+func (m Money[U]) MarshalJSON() ([]byte, error) {
+	return m.Currency().MarshalJSON()
+}
+
+// UnmarshalJSON decodes m from any of the JSON formats of Currency. The currency, if present,
+// must be U.
+func (m *Money[U]) UnmarshalJSON(data []byte) error {
+	c := MoneyFromFractional[U](0).Currency()
+	err := json.Unmarshal(data, c)
+	if err != nil {
+		return err
+	}
+
+	nm, err := MoneyFrom[U](c)
+	if err != nil {
+		return err
+	}
+
+	*m = nm
+	return nil
+}
+
+// Units of commonly used currencies, e.g. Money[USD]. Their meta data is fixed at compile time,
+// and is not affected by changes to the registry.
+type (
+	// AUD is the unit of the Australian dollar
+	AUD struct{}
+	// CAD is the unit of the Canadian dollar
+	CAD struct{}
+	// CHF is the unit of the Swiss franc
+	CHF struct{}
+	// CNY is the unit of the Chinese yuan renminbi
+	CNY struct{}
+	// EUR is the unit of the euro
+	EUR struct{}
+	// GBP is the unit of the pound sterling
+	GBP struct{}
+	// INR is the unit of the Indian rupee
+	INR struct{}
+	// JPY is the unit of the Japanese yen
+	JPY struct{}
+	// SGD is the unit of the Singapore dollar
+	SGD struct{}
+	// USD is the unit of the US dollar
+	USD struct{}
+)
+
+// Meta returns the meta data of AUD
+func (AUD) Meta() Meta {
+	return Meta{Code: "AUD", Numeric: 36, Symbol: "$", FUName: "cent", FUShare: 100}
+}
+
+// Meta returns the meta data of CAD
+func (CAD) Meta() Meta {
+	return Meta{Code: "CAD", Numeric: 124, Symbol: "$", FUName: "cent", FUShare: 100}
+}
+
+// Meta returns the meta data of CHF
+func (CHF) Meta() Meta {
+	return Meta{Code: "CHF", Numeric: 756, Symbol: "Fr.", FUName: "rappen", FUShare: 100}
+}
+
+// Meta returns the meta data of CNY
+func (CNY) Meta() Meta {
+	return Meta{Code: "CNY", Numeric: 156, Symbol: "¥", FUName: "fen", FUShare: 100}
+}
+
+// Meta returns the meta data of EUR
+func (EUR) Meta() Meta {
+	return Meta{Code: "EUR", Numeric: 978, Symbol: "€", FUName: "cent", FUShare: 100}
+}
+
+// Meta returns the meta data of GBP
+func (GBP) Meta() Meta {
+	return Meta{Code: "GBP", Numeric: 826, Symbol: "£", FUName: "penny", FUShare: 100}
+}
+
+// Meta returns the meta data of INR
+func (INR) Meta() Meta {
+	return Meta{Code: "INR", Numeric: 356, Symbol: "₹", FUName: "paise", FUShare: 100}
+}
+
+// Meta returns the meta data of JPY
+func (JPY) Meta() Meta {
+	return Meta{Code: "JPY", Numeric: 392, Symbol: "¥", FUName: "", FUShare: 1}
+}
+
+// Meta returns the meta data of SGD
+func (SGD) Meta() Meta {
+	return Meta{Code: "SGD", Numeric: 702, Symbol: "$", FUName: "cent", FUShare: 100}
+}
+
+// Meta returns the meta data of USD
+func (USD) Meta() Meta {
+	return Meta{Code: "USD", Numeric: 840, Symbol: "$", FUName: "cent", FUShare: 100}
+}
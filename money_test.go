@@ -0,0 +1,133 @@
+package currency
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// xts is a custom unit, which is not in the registry
+type xts struct{}
+
+func (xts) Meta() Meta {
+	return Meta{Code: "XTS", Symbol: "T", FUName: "tick", FUShare: 1000}
+}
+
+func TestMoney(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	a, err := NewMoney[USD](10, 50)
+	requirer.NoError(err)
+	b := MoneyFromFractional[USD](275)
+
+	// a.Add(MoneyFromFractional[EUR](1)) does not compile
+	asserter.Equal(1325, a.Add(b).FractionalTotal())
+	asserter.Equal(775, a.Subtract(b).FractionalTotal())
+	asserter.Equal(-2100, a.Multiply(-2).FractionalTotal())
+	asserter.Equal(-1050, a.Negate().FractionalTotal())
+	asserter.Equal(1, a.Cmp(b))
+	asserter.Equal(-1, b.Cmp(a))
+	asserter.Equal(0, a.Cmp(a))
+	asserter.True(Money[USD]{}.IsZero())
+	asserter.False(a.IsZero())
+	// the operations return new values
+	asserter.Equal(1050, a.FractionalTotal())
+
+	asserter.Equal("USD", a.Meta().Code)
+	asserter.Equal("$", a.Meta().Symbol)
+
+	c, err := NewMoney[USD](1, 150)
+	requirer.NoError(err)
+	asserter.Equal(250, c.FractionalTotal())
+
+	c, err = NewMoney[USD](-1, 50)
+	requirer.NoError(err)
+	asserter.Equal(-150, c.FractionalTotal())
+
+	tick := MoneyFromFractional[xts](-1005)
+	asserter.Equal("-1.005", tick.Currency().StringWithoutSymbols())
+}
+
+func TestMoneyCurrency(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	m := MoneyFromFractional[INR](-1050)
+	c := m.Currency()
+	asserter.Equal("INR", c.Code)
+	asserter.Equal("₹", c.Symbol)
+	asserter.Equal("paise", c.FUName)
+	asserter.Equal(uint(100), c.FUShare)
+	asserter.Equal(-10, c.Main)
+	asserter.Equal(50, c.Fractional)
+	asserter.Equal(c.String(), m.String())
+
+	back, err := MoneyFrom[INR](c)
+	requirer.NoError(err)
+	asserter.Equal(m, back)
+
+	_, err = MoneyFrom[USD](c)
+	asserter.ErrorIs(err, ErrMismatchCurrency)
+
+	mills, err := NewFractional(1005, "USD", "$", "mill", 1000)
+	requirer.NoError(err)
+	_, err = MoneyFrom[USD](mills)
+	asserter.ErrorIs(err, ErrMismatchCurrency)
+	me := &MismatchError{}
+	requirer.ErrorAs(err, &me)
+	asserter.Equal(MismatchError{Op: "MoneyFrom", Code: "USD", FUShare: 100, OtherCode: "USD", OtherFUShare: 1000}, *me)
+
+	jpy, err := MoneyFrom[JPY](&Currency{Code: "JPY", FUShare: 1, Main: 500})
+	requirer.NoError(err)
+	asserter.Equal(500, jpy.FractionalTotal())
+}
+
+func TestMoneyJSON(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	type invoice struct {
+		Total Money[EUR] `json:"total"`
+	}
+
+	data, err := json.Marshal(invoice{Total: MoneyFromFractional[EUR](123456)})
+	requirer.NoError(err)
+	asserter.JSONEq(`{"total":{"amount":"1234.56","currency":"EUR"}}`, string(data))
+
+	inv := invoice{}
+	requirer.NoError(json.Unmarshal(data, &inv))
+	asserter.Equal(123456, inv.Total.FractionalTotal())
+
+	requirer.NoError(json.Unmarshal([]byte(`{"total":"EUR 10.50"}`), &inv))
+	asserter.Equal(1050, inv.Total.FractionalTotal())
+
+	err = json.Unmarshal([]byte(`{"total":{"amount":"10.50","currency":"USD"}}`), &inv)
+	asserter.ErrorIs(err, ErrMismatchCurrency)
+}
+
+func TestMoneyBuiltinUnits(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	units := []Unit{AUD{}, CAD{}, CHF{}, CNY{}, EUR{}, GBP{}, INR{}, JPY{}, SGD{}, USD{}}
+	for _, u := range units {
+		m, err := Lookup(u.Meta().Code)
+		requirer.NoError(err)
+		asserter.Equal(m, u.Meta())
+	}
+
+	// the units are not affected by changes to the registry
+	old, err := Lookup("USD")
+	requirer.NoError(err)
+	requirer.NoError(Register(Meta{Code: "USD", Symbol: "$", FUName: "mill", FUShare: 1000}))
+	defer func() {
+		requirer.NoError(Register(old))
+	}()
+
+	m := MoneyFromFractional[USD](1250)
+	asserter.Equal("12.50", m.Currency().StringWithoutSymbols())
+	asserter.Equal(uint(100), USD{}.Meta().FUShare)
+}
@@ -1,8 +1,25 @@
 package currency
 
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
 // UpdateWithFractional will update all the relevant values of currency based on the
-// fractional unit provided.
+// fractional unit provided. If c has an invalid fractional unit share, e.g. the zero value,
+// c is left unchanged. Use UpdateWithFractionalE to get the error instead.
 func (c *Currency) UpdateWithFractional(frac int) {
+	_ = c.UpdateWithFractionalE(frac)
+}
+
+// UpdateWithFractionalE is same as UpdateWithFractional, except that it returns ErrInvalidFUS
+// if c has an invalid fractional unit share.
+func (c *Currency) UpdateWithFractionalE(frac int) error {
+	if c.FUShare == 0 {
+		return ErrInvalidFUS
+	}
+
 	fus := int(c.FUShare)
 
 	c.Main = (frac / fus)
@@ -11,67 +28,128 @@ func (c *Currency) UpdateWithFractional(frac int) {
 	if c.Main < 0 {
 		c.Fractional = -c.Fractional
 	}
+
+	return nil
+}
+
+// adopt sets the meta data of c from ref, if c is the zero value of Currency.
+func (c *Currency) adopt(ref Currency) {
+	if !c.isBlank() {
+		return
+	}
+
+	pre, suf := c.PrefixSymbol, c.SuffixSymbol
+	*c = ref
+	c.Main = 0
+	c.Fractional = 0
+	c.PrefixSymbol = c.PrefixSymbol || pre
+	c.SuffixSymbol = c.SuffixSymbol || suf
 }
 
 // Add adds the given currency with the base currency.
 func (c *Currency) Add(acur Currency) error {
-	if c.Code != acur.Code {
-		return ErrMismatchCurrency
+	c.adopt(acur)
+	err := c.match("Add", &acur)
+	if err != nil {
+		return err
 	}
 
-	c.UpdateWithFractional(c.FractionalTotal() + acur.FractionalTotal())
-	return nil
+	return c.UpdateWithFractionalE(c.FractionalTotal() + acur.FractionalTotal())
 }
 
-// AddInt adds main & fractional value provided to the currency
+// AddInt adds main & fractional value provided to the currency. If c has an invalid
+// fractional unit share, c is left unchanged. Use AddIntE to get the error instead.
 func (c *Currency) AddInt(main int, frac int) {
+	_ = c.AddIntE(main, frac)
+}
+
+// AddIntE is same as AddInt, except that it returns ErrInvalidFUS if c has an invalid
+// fractional unit share.
+func (c *Currency) AddIntE(main int, frac int) error {
 	if main < 0 && frac > 0 {
 		frac = -frac
 	}
 
-	c.UpdateWithFractional(c.FractionalTotal() + main*int(c.FUShare) + frac)
+	return c.UpdateWithFractionalE(c.FractionalTotal() + main*int(c.FUShare) + frac)
 }
 
-// SubtractInt subtracts main & fractional value provided from the currency
+// SubtractInt subtracts main & fractional value provided from the currency. If c has an
+// invalid fractional unit share, c is left unchanged. Use SubtractIntE to get the error instead.
 func (c *Currency) SubtractInt(main int, frac int) {
+	_ = c.SubtractIntE(main, frac)
+}
+
+// SubtractIntE is same as SubtractInt, except that it returns ErrInvalidFUS if c has an
+// invalid fractional unit share.
+func (c *Currency) SubtractIntE(main int, frac int) error {
 	if main < 0 && frac > 0 {
 		frac = -frac
 	}
 
-	c.UpdateWithFractional(c.FractionalTotal() - (main*int(c.FUShare) + frac))
+	return c.UpdateWithFractionalE(c.FractionalTotal() - (main*int(c.FUShare) + frac))
 }
 
 // Subtract subtracts the given currency from the base currency.
 func (c *Currency) Subtract(scur Currency) error {
-	if c.Code != scur.Code {
-		return ErrMismatchCurrency
+	c.adopt(scur)
+	err := c.match("Subtract", &scur)
+	if err != nil {
+		return err
 	}
 
-	c.UpdateWithFractional(c.FractionalTotal() - scur.FractionalTotal())
-	return nil
+	return c.UpdateWithFractionalE(c.FractionalTotal() - scur.FractionalTotal())
 }
 
-// Percent returns a new instance of currency which is n percent of c.
+// Percent returns a new instance of currency which is n percent of c. It returns nil if c has
+// an invalid fractional unit share. Use PercentE to get the error instead.
 func (c *Currency) Percent(n float64) *Currency {
-	totalFrac := round(float64(c.FractionalTotal())*(n/100.00), c.magnitude)
+	c1, _ := c.PercentE(n)
+	return c1
+}
+
+// PercentE is same as Percent, except that it returns ErrInvalidFUS if c has an invalid
+// fractional unit share.
+func (c *Currency) PercentE(n float64) (*Currency, error) {
+	_, mag := c.precision()
+	totalFrac := round(float64(c.FractionalTotal())*(n/100.00), mag)
 	c1 := *c
-	c1.UpdateWithFractional(totalFrac)
-	return &c1
+	err := c1.UpdateWithFractionalE(totalFrac)
+	if err != nil {
+		return nil, err
+	}
+
+	return &c1, nil
 }
 
-// Multiply multiplies the currency by an integer.
+// Multiply multiplies the currency by an integer. If c has an invalid fractional unit share,
+// c is left unchanged. Use MultiplyE to get the error instead.
 func (c *Currency) Multiply(by int) {
-	c.UpdateWithFractional(c.FractionalTotal() * by)
+	_ = c.MultiplyE(by)
 }
 
-// MultiplyFloat64 multiplies the currency by a float value.
+// MultiplyE is same as Multiply, except that it returns ErrInvalidFUS if c has an invalid
+// fractional unit share.
+func (c *Currency) MultiplyE(by int) error {
+	return c.UpdateWithFractionalE(c.FractionalTotal() * by)
+}
+
+// MultiplyFloat64 multiplies the currency by a float value. If c has an invalid fractional
+// unit share, c is left unchanged. Use MultiplyFloat64E to get the error instead.
 func (c *Currency) MultiplyFloat64(by float64) {
+	_ = c.MultiplyFloat64E(by)
+}
+
+// MultiplyFloat64E is same as MultiplyFloat64, except that it returns ErrInvalidFUS if c has
+// an invalid fractional unit share.
+func (c *Currency) MultiplyFloat64E(by float64) error {
+	_, mag := c.precision()
 	t := float64(c.FractionalTotal()) * by
-	c.UpdateWithFractional(round(t, c.magnitude))
+	return c.UpdateWithFractionalE(round(t, mag))
 }
 
-// Divide is a deprecated method which does allocations
-// Deprecated: Divide is not the technical term when dealing with currency.
+// Divide is a deprecated method which does allocations, same as Allocate.
+// Deprecated: Divide is not the technical term when dealing with currency, use Allocate or
+// AllocateE instead.
 func (c *Currency) Divide(by int, retain bool) ([]Currency, bool) {
 	return c.Allocate(by, retain)
 }
@@ -82,8 +160,25 @@ func (c *Currency) Divide(by int, retain bool) ([]Currency, bool) {
    instead retained inside c. It returns a list because, when the currency cannot
    be split/divided equally, then the remainder has to be distributed.
    The bool value if `true`, means the currency was split equally.
+   It returns (nil, false) if c has an invalid fractional unit share, or if by is less
+   than 1. Use AllocateE to get the error instead.
 */
 func (c *Currency) Allocate(by int, retain bool) ([]Currency, bool) {
+	d, sE, _ := c.AllocateE(by, retain)
+	return d, sE
+}
+
+// AllocateE is same as Allocate, except that it returns ErrInvalidFUS if c has an invalid
+// fractional unit share, and ErrInvalidAllocation if by is less than 1.
+func (c *Currency) AllocateE(by int, retain bool) ([]Currency, bool, error) {
+	if c.FUShare == 0 {
+		return nil, false, ErrInvalidFUS
+	}
+
+	if by < 1 {
+		return nil, false, ErrInvalidAllocation
+	}
+
 	sE := false
 
 	ft := c.FractionalTotal()
@@ -111,5 +206,120 @@ func (c *Currency) Allocate(by int, retain bool) ([]Currency, bool) {
 		c.UpdateWithFractional(balance)
 	}
 
-	return d, sE
+	return d, sE, nil
+}
+
+// Compare compares c with o, and returns -1 if c < o, 0 if c == o, and +1 if c > o. It
+// returns a *MismatchError if the currencies don't match.
+func (c *Currency) Compare(o Currency) (int, error) {
+	err := c.match("Compare", &o)
+	if err != nil {
+		return 0, err
+	}
+
+	a, b := c.FractionalTotal(), o.FractionalTotal()
+	switch {
+	case a < b:
+		return -1, nil
+	case a > b:
+		return 1, nil
+	}
+
+	return 0, nil
+}
+
+// Equal returns true if c & o have the same amount. It returns a *MismatchError if the
+// currencies don't match.
+func (c *Currency) Equal(o Currency) (bool, error) {
+	err := c.match("Equal", &o)
+	if err != nil {
+		return false, err
+	}
+
+	return c.FractionalTotal() == o.FractionalTotal(), nil
+}
+
+// AllocateRatios allocates the currency in proportion to the given ratios, e.g. 1, 2 allocates
+// ⅓ & ⅔. The allocated amounts always add up to c. The fractional units which remain after
+// rounding down every share are distributed one each, to the shares with the largest remainders.
+func (c *Currency) AllocateRatios(ratios ...int) ([]Currency, error) {
+	weights := make([]*big.Int, 0, len(ratios))
+	for _, r := range ratios {
+		weights = append(weights, big.NewInt(int64(r)))
+	}
+
+	return c.allocate(weights)
+}
+
+// AllocateByWeights allocates the currency in proportion to the given amounts, e.g. a discount
+// allocated across the lines of an invoice. It's the same as AllocateRatios with the fractional
+// totals of the weights, which must match c, otherwise a *MismatchError is returned.
+func (c *Currency) AllocateByWeights(weights ...Currency) ([]Currency, error) {
+	ws := make([]*big.Int, 0, len(weights))
+	for i := range weights {
+		err := c.match("AllocateByWeights", &weights[i])
+		if err != nil {
+			return nil, err
+		}
+
+		ws = append(ws, big.NewInt(int64(weights[i].FractionalTotal())))
+	}
+
+	return c.allocate(ws)
+}
+
+// allocate allocates the currency in proportion to the weights, using the largest remainder method
+func (c *Currency) allocate(weights []*big.Int) ([]Currency, error) {
+	if c.FUShare == 0 {
+		return nil, ErrInvalidFUS
+	}
+
+	total := new(big.Int)
+	for _, w := range weights {
+		if w.Sign() < 0 {
+			return nil, fmt.Errorf("%w: negative weight %s", ErrInvalidAllocation, w)
+		}
+		total.Add(total, w)
+	}
+
+	if total.Sign() == 0 {
+		return nil, fmt.Errorf("%w: weights must add up to more than 0", ErrInvalidAllocation)
+	}
+
+	ft := c.FractionalTotal()
+	sign := 1
+	if ft < 0 {
+		sign = -1
+	}
+	amount := big.NewInt(int64(ft * sign))
+
+	shares := make([]int, len(weights))
+	remainders := make([]*big.Int, len(weights))
+	left := ft * sign
+	for i, w := range weights {
+		q, r := new(big.Int).QuoRem(new(big.Int).Mul(amount, w), total, new(big.Int))
+		shares[i] = int(q.Int64())
+		remainders[i] = r
+		left -= shares[i]
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]].Cmp(remainders[order[j]]) > 0
+	})
+
+	for i := 0; i < left; i++ {
+		shares[order[i]]++
+	}
+
+	allocated := make([]Currency, len(weights))
+	for i, share := range shares {
+		allocated[i] = *c
+		allocated[i].UpdateWithFractional(share * sign)
+	}
+
+	return allocated, nil
 }
@@ -198,6 +198,7 @@ func TestPercent(t *testing.T) {
 	requirer.NoError(err)
 
 	cur2 := cur1.Percent(5.25)
+	requirer.NotNil(cur2)
 
 	asserter.Equal(0, cur2.Main)
 	asserter.Equal(55, cur2.Fractional)
@@ -267,6 +268,233 @@ func TestDivideNoRetain(t *testing.T) {
 	}
 }
 
+func TestZeroValue(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	var zero Currency
+	asserter.Equal(0, zero.FractionalTotal())
+	asserter.Equal(0.0, zero.Float64())
+	asserter.Equal("0", zero.String())
+
+	asserter.ErrorIs(zero.UpdateWithFractionalE(100), ErrInvalidFUS)
+	asserter.ErrorIs(zero.AddIntE(1, 0), ErrInvalidFUS)
+	asserter.ErrorIs(zero.SubtractIntE(1, 0), ErrInvalidFUS)
+	asserter.ErrorIs(zero.MultiplyE(2), ErrInvalidFUS)
+	asserter.ErrorIs(zero.MultiplyFloat64E(2), ErrInvalidFUS)
+
+	_, err := zero.PercentE(10)
+	asserter.ErrorIs(err, ErrInvalidFUS)
+
+	_, _, err = zero.AllocateE(3, false)
+	asserter.ErrorIs(err, ErrInvalidFUS)
+
+	// the variants without an error don't panic, and leave the zero value unchanged
+	asserter.NotPanics(func() {
+		zero.UpdateWithFractional(100)
+		zero.AddInt(1, 0)
+		zero.SubtractInt(1, 0)
+		zero.Multiply(2)
+		zero.MultiplyFloat64(2)
+		asserter.Nil(zero.Percent(10))
+
+		splits, ok := zero.Allocate(3, false)
+		asserter.Nil(splits)
+		asserter.False(ok)
+
+		splits, ok = zero.Divide(3, false)
+		asserter.Nil(splits)
+		asserter.False(ok)
+	})
+	asserter.Equal(Currency{}, zero)
+
+	cur, err := New(10, 50, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+
+	var total Currency
+	total.PrefixSymbol = true
+	requirer.NoError(total.Add(*cur))
+	requirer.NoError(total.Add(*cur))
+	asserter.Equal("INR", total.Code)
+	asserter.Equal(uint(100), total.FUShare)
+	asserter.Equal("₹21.00", total.String())
+
+	var balance Currency
+	requirer.NoError(balance.Subtract(*cur))
+	asserter.Equal(-1050, balance.FractionalTotal())
+	asserter.Equal("-10.50", balance.String())
+
+	invalid := Currency{Code: "INR", Main: 1}
+	asserter.ErrorIs(invalid.Add(*cur), ErrInvalidFUS)
+}
+
+func TestAllocateInvalid(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	cur, err := New(1, 0, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+
+	_, _, err = cur.AllocateE(0, false)
+	asserter.ErrorIs(err, ErrInvalidAllocation)
+
+	splits, ok := cur.Divide(0, false)
+	asserter.Nil(splits)
+	asserter.False(ok)
+
+	_, _, err = cur.AllocateE(-2, true)
+	asserter.ErrorIs(err, ErrInvalidAllocation)
+	asserter.Equal(100, cur.FractionalTotal())
+}
+
+func TestMismatchError(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	inr, err := New(1, 0, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+	usd, err := New(1, 0, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+	mills, err := New(1, 0, "USD", "$", "mill", 1000)
+	requirer.NoError(err)
+
+	err = inr.Add(*usd)
+	asserter.ErrorIs(err, ErrMismatchCurrency)
+	asserter.EqualError(err, "currencies do not match: Add: INR and USD")
+
+	me := &MismatchError{}
+	requirer.ErrorAs(err, &me)
+	asserter.Equal(MismatchError{Op: "Add", Code: "INR", FUShare: 100, OtherCode: "USD", OtherFUShare: 100}, *me)
+
+	err = usd.Subtract(*mills)
+	asserter.EqualError(err, "currencies do not match: Subtract: USD with fractional share 100 and USD with fractional share 1000")
+	requirer.ErrorAs(err, &me)
+	asserter.Equal("Subtract", me.Op)
+	asserter.Equal(uint(1000), me.OtherFUShare)
+	asserter.Equal(100, usd.FractionalTotal())
+
+	_, err = inr.Compare(*usd)
+	requirer.ErrorAs(err, &me)
+	asserter.Equal("Compare", me.Op)
+
+	_, err = inr.Equal(*usd)
+	requirer.ErrorAs(err, &me)
+	asserter.Equal("Equal", me.Op)
+
+	_, err = inr.AllocateByWeights(*inr, *usd)
+	requirer.ErrorAs(err, &me)
+	asserter.Equal("AllocateByWeights", me.Op)
+}
+
+func TestCompare(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	a, err := New(10, 50, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+	b, err := New(-10, 50, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+
+	cmp, err := a.Compare(*b)
+	requirer.NoError(err)
+	asserter.Equal(1, cmp)
+
+	cmp, err = b.Compare(*a)
+	requirer.NoError(err)
+	asserter.Equal(-1, cmp)
+
+	cmp, err = a.Compare(*a)
+	requirer.NoError(err)
+	asserter.Equal(0, cmp)
+
+	eq, err := a.Equal(*a)
+	requirer.NoError(err)
+	asserter.True(eq)
+
+	eq, err = a.Equal(*b)
+	requirer.NoError(err)
+	asserter.False(eq)
+
+	_, err = (&Currency{}).Compare(*a)
+	asserter.ErrorIs(err, ErrInvalidFUS)
+}
+
+func TestAllocateRatios(t *testing.T) {
+	tests := []struct {
+		name   string
+		ftotal int
+		ratios []int
+		want   []int
+	}{
+		{name: "equal", ftotal: 100, ratios: []int{1, 1, 1}, want: []int{34, 33, 33}},
+		{name: "largest remainder", ftotal: 100, ratios: []int{1, 2}, want: []int{33, 67}},
+		{name: "percentages", ftotal: 1001, ratios: []int{70, 20, 10}, want: []int{701, 200, 100}},
+		{name: "zero ratio", ftotal: 5, ratios: []int{0, 1, 1}, want: []int{0, 3, 2}},
+		{name: "negative amount", ftotal: -100, ratios: []int{1, 2}, want: []int{-33, -67}},
+		{name: "zero amount", ftotal: 0, ratios: []int{1, 2}, want: []int{0, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cur, err := NewFractional(tt.ftotal, "INR", "₹", "paise", 100)
+			require.NoError(t, err)
+
+			allocated, err := cur.AllocateRatios(tt.ratios...)
+			require.NoError(t, err)
+
+			got := []int{}
+			sum := 0
+			for _, a := range allocated {
+				got = append(got, a.FractionalTotal())
+				sum += a.FractionalTotal()
+				assert.Equal(t, "INR", a.Code)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.ftotal, sum)
+		})
+	}
+
+	cur, err := New(1, 0, "INR", "₹", "paise", 100)
+	require.NoError(t, err)
+
+	_, err = cur.AllocateRatios()
+	assert.ErrorIs(t, err, ErrInvalidAllocation)
+
+	_, err = cur.AllocateRatios(0, 0)
+	assert.ErrorIs(t, err, ErrInvalidAllocation)
+
+	_, err = cur.AllocateRatios(1, -1, 2)
+	assert.ErrorIs(t, err, ErrInvalidAllocation)
+
+	_, err = (&Currency{}).AllocateRatios(1, 2)
+	assert.ErrorIs(t, err, ErrInvalidFUS)
+}
+
+func TestAllocateByWeights(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	discount, err := New(10, 0, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	lines := make([]Currency, 0, 3)
+	for _, ft := range []int{1999, 500, 4999} {
+		line, err := NewFractional(ft, "USD", "$", "cent", 100)
+		requirer.NoError(err)
+		lines = append(lines, *line)
+	}
+
+	allocated, err := discount.AllocateByWeights(lines...)
+	requirer.NoError(err)
+
+	got := []string{}
+	for _, a := range allocated {
+		got = append(got, a.StringWithoutSymbols())
+	}
+	// 1000 × 1999/7498 = 266.60, 1000 × 500/7498 = 66.68, 1000 × 4999/7498 = 666.71
+	asserter.Equal([]string{"2.66", "0.67", "6.67"}, got)
+}
+
 func BenchmarkUpdateWithFractional(t *testing.B) {
 	cur, _ := New(1, 0, "INR", "₹", "paise", 100)
 	for i := 0; i < t.N; i++ {
@@ -323,7 +551,7 @@ func BenchmarkMultiplyFloat64(t *testing.B) {
 func BenchmarkPercent(t *testing.B) {
 	cur, _ := New(1, 0, "INR", "₹", "paise", 100)
 	for i := 0; i < t.N; i++ {
-		cur.Percent(12.18)
+		_ = cur.Percent(12.18)
 	}
 }
 
@@ -0,0 +1,120 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrRateNotFound is the error returned by a RateProvider when it has no rate for the requested pair
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// RateProvider provides the exchange rate from base to quote, effective at the given time.
+type RateProvider interface {
+	Rate(ctx context.Context, base, quote string, at time.Time) (ExchangeRate, error)
+}
+
+// pair is a currency pair used as a map key
+type pair struct {
+	base  string
+	quote string
+}
+
+// RateTable is an in-memory RateProvider holding a single rate per currency pair, irrespective
+// of the time requested. A rate is also used for the inverse pair, if the inverse is not set.
+// The rates are copied when set & returned, so they can't be modified by the callers.
+type RateTable struct {
+	mu    sync.RWMutex
+	rates map[pair]ExchangeRate
+}
+
+// NewRateTable returns a new rate table with the given rates.
+func NewRateTable(rates ...ExchangeRate) (*RateTable, error) {
+	rt := &RateTable{rates: make(map[pair]ExchangeRate, len(rates))}
+	for _, er := range rates {
+		err := rt.Set(er)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rt, nil
+}
+
+// Set adds or replaces the rate of a currency pair.
+func (rt *RateTable) Set(er ExchangeRate) error {
+	return rt.set(er, false)
+}
+
+// setLatest sets the rate of a currency pair, unless the table already has a later rate for the pair.
+func (rt *RateTable) setLatest(er ExchangeRate) error {
+	return rt.set(er, true)
+}
+
+// set sets the rate of a currency pair. If latest is true, the rate is not set if the table
+// already has a later rate for the pair.
+func (rt *RateTable) set(er ExchangeRate, latest bool) error {
+	err := er.Validate()
+	if err != nil {
+		return err
+	}
+
+	p := pair{base: er.Base, quote: er.Quote}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if old, ok := rt.rates[p]; latest && ok && old.Time.After(er.Time) {
+		return nil
+	}
+
+	if rt.rates == nil {
+		rt.rates = make(map[pair]ExchangeRate)
+	}
+	rt.rates[p] = er.clone()
+
+	return nil
+}
+
+// Rate implements RateProvider. The time is ignored, since the table holds a single rate per pair.
+func (rt *RateTable) Rate(ctx context.Context, base, quote string, at time.Time) (ExchangeRate, error) {
+	if base == quote {
+		return ExchangeRate{Base: base, Quote: quote, Rate: big.NewRat(1, 1), Time: at}, nil
+	}
+
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+
+	if er, ok := rt.rates[pair{base: base, quote: quote}]; ok {
+		return er.clone(), nil
+	}
+
+	if er, ok := rt.rates[pair{base: quote, quote: base}]; ok {
+		return er.Inverse(), nil
+	}
+
+	return ExchangeRate{}, fmt.Errorf("%w: %s/%s", ErrRateNotFound, base, quote)
+}
+
+// Rates returns all the rates in the table, sorted by base & quote.
+func (rt *RateTable) Rates() []ExchangeRate {
+	rt.mu.RLock()
+	rates := make([]ExchangeRate, 0, len(rt.rates))
+	for _, er := range rt.rates {
+		rates = append(rates, er.clone())
+	}
+	rt.mu.RUnlock()
+
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].Base != rates[j].Base {
+			return rates[i].Base < rates[j].Base
+		}
+		return rates[i].Quote < rates[j].Quote
+	})
+
+	return rates
+}
@@ -0,0 +1,111 @@
+package currency
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateTable(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	usdinr, err := NewExchangeRate("USD", "INR", "83.1275", now)
+	requirer.NoError(err)
+
+	eurusd, err := NewExchangeRate("EUR", "USD", "1.0811", now)
+	requirer.NoError(err)
+
+	rt, err := NewRateTable(usdinr, eurusd)
+	requirer.NoError(err)
+
+	er, err := rt.Rate(ctx, "USD", "INR", now)
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83.1275", er.String())
+
+	er, err = rt.Rate(ctx, "USD", "EUR", now)
+	requirer.NoError(err)
+	asserter.Equal("USD/EUR 10000/10811", er.String())
+
+	er, err = rt.Rate(ctx, "JPY", "JPY", now)
+	requirer.NoError(err)
+	asserter.Equal("JPY/JPY 1", er.String())
+
+	_, err = rt.Rate(ctx, "USD", "JPY", now)
+	asserter.ErrorIs(err, ErrRateNotFound)
+
+	asserter.ErrorIs(rt.Set(ExchangeRate{Base: "USD", Quote: "JPY", Rate: big.NewRat(0, 1)}), ErrInvalidRate)
+
+	_, err = NewRateTable(ExchangeRate{Base: "USD", Quote: "JPY"})
+	asserter.ErrorIs(err, ErrInvalidRate)
+
+	zero := RateTable{}
+	requirer.NoError(zero.Set(usdinr))
+
+	rates := rt.Rates()
+	requirer.Len(rates, 2)
+	asserter.Equal("EUR", rates[0].Base)
+	asserter.Equal("USD", rates[1].Base)
+}
+
+func TestRateTableCopies(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	ctx := context.Background()
+	usdinr, err := NewExchangeRate("USD", "INR", "83.1275", time.Time{})
+	requirer.NoError(err)
+
+	rt, err := NewRateTable(usdinr)
+	requirer.NoError(err)
+
+	// neither the rate set, nor the rates returned share the rate of the table
+	usdinr.Rate.SetInt64(1)
+	er, err := rt.Rate(ctx, "USD", "INR", time.Time{})
+	requirer.NoError(err)
+	er.Rate.SetInt64(2)
+	rt.Rates()[0].Rate.SetInt64(3)
+
+	er, err = rt.Rate(ctx, "USD", "INR", time.Time{})
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83.1275", er.String())
+}
+
+func TestRateTableSetLatest(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	day := time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC)
+	rates := make([]ExchangeRate, 0, 50)
+	for i := 0; i < 50; i++ {
+		er, err := NewExchangeRate("USD", "INR", "83", day.AddDate(0, 0, i))
+		requirer.NoError(err)
+		rates = append(rates, er)
+	}
+
+	// concurrent loaders never replace a later rate with an earlier one
+	for run := 0; run < 20; run++ {
+		rt := &RateTable{}
+		wg := sync.WaitGroup{}
+		for _, er := range rates {
+			wg.Add(1)
+			go func(er ExchangeRate) {
+				defer wg.Done()
+				asserter.NoError(rt.setLatest(er))
+			}(er)
+		}
+		wg.Wait()
+
+		latest := rt.Rates()
+		requirer.Len(latest, 1)
+		asserter.True(latest[0].Time.Equal(rates[len(rates)-1].Time))
+	}
+}
@@ -0,0 +1,124 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Pricing is the markup & fee applied over the mid rate by Converter.Quote.
+type Pricing struct {
+	// Spread is the fraction of the mid rate retained as markup, e.g. 0.015 for 1.5%. The
+	// applied rate is mid rate × (1 - Spread). nil means no spread.
+	Spread *big.Rat
+	// Fee is the fixed fee in the source currency, charged in addition to the amount converted.
+	// nil means no fee.
+	Fee *Currency
+	// Validity is the duration from the time of the quote for which it's valid. 0 means the
+	// quote doesn't expire.
+	Validity time.Duration
+}
+
+// Quote is a priced conversion of an amount, holding the exact numbers it's derived from, so
+// that the amounts shown & booked are the same.
+type Quote struct {
+	// Source is the amount converted
+	Source *Currency
+	// MidRate is the direct or cross rate from the source to the target currency
+	MidRate CrossRate
+	// AppliedRate is the mid rate after the spread, used to convert Source to Target
+	AppliedRate ExchangeRate
+	// Spread is the fraction of the mid rate retained as markup
+	Spread *big.Rat
+	// Fee is the fixed fee in the source currency, charged in addition to Source
+	Fee *Currency
+	// Target is the converted amount, i.e. Source × AppliedRate
+	Target *Currency
+	// Time is the time of the quote, which is also the effective time of the rates used
+	Time time.Time
+	// ExpiresAt is the time after which the quote is not valid. Zero if it doesn't expire.
+	ExpiresAt time.Time
+}
+
+// Quote prices the conversion of c to the currency `to` at the given time. The applied rate is
+// the mid rate less the spread, rounded to Precision decimal places if set, and the target
+// amount is rounded to its fractional unit using Rounding.
+func (cv *Converter) Quote(ctx context.Context, c *Currency, to string, at time.Time, pricing Pricing) (*Quote, error) {
+	if c.FUShare == 0 {
+		return nil, ErrInvalidFUS
+	}
+
+	spread := new(big.Rat)
+	if pricing.Spread != nil {
+		spread.Set(pricing.Spread)
+	}
+
+	if spread.Sign() < 0 || spread.Cmp(big.NewRat(1, 1)) >= 0 {
+		return nil, fmt.Errorf("%w: spread %s must be at least 0 and less than 1", ErrInvalidRate, ratString(spread))
+	}
+
+	fee := *c
+	fee.Main, fee.Fractional = 0, 0
+	if pricing.Fee != nil {
+		err := c.match("Quote", pricing.Fee)
+		if err != nil {
+			return nil, err
+		}
+		fee = *pricing.Fee
+	}
+
+	m, err := Lookup(to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", err, to)
+	}
+
+	mid, err := cv.CrossRate(ctx, c.Code, to, at)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := new(big.Rat).Sub(big.NewRat(1, 1), spread)
+	applied, err = cv.roundRate(applied.Mul(applied, mid.Rate))
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := convert(c, m, applied, cv.Rounding)
+	if err != nil {
+		return nil, err
+	}
+
+	source := *c
+	q := &Quote{
+		Source:      &source,
+		MidRate:     mid,
+		AppliedRate: ExchangeRate{Base: c.Code, Quote: to, Rate: applied, Time: mid.Time},
+		Spread:      spread,
+		Fee:         &fee,
+		Target:      target,
+		Time:        at,
+	}
+
+	if pricing.Validity > 0 {
+		q.ExpiresAt = at.Add(pricing.Validity)
+	}
+
+	return q, nil
+}
+
+// Total returns the total amount charged in the source currency, i.e. Source + Fee.
+func (q *Quote) Total() (*Currency, error) {
+	total := *q.Source
+	err := total.Add(*q.Fee)
+	if err != nil {
+		return nil, err
+	}
+
+	return &total, nil
+}
+
+// Expired returns true if the quote is not valid at the given time.
+func (q *Quote) Expired(t time.Time) bool {
+	return !q.ExpiresAt.IsZero() && t.After(q.ExpiresAt)
+}
@@ -0,0 +1,83 @@
+package currency
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConverterQuote(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	ctx := context.Background()
+	cv := &Converter{
+		Rates:     newTestRateTable(t, [3]string{"USD", "INR", "83.1275"}),
+		Precision: 4,
+		Rounding:  RoundHalfEven,
+	}
+
+	usd, err := New(1000, 0, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	fee, err := New(2, 50, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	at := time.Date(2024, 3, 28, 10, 0, 0, 0, time.UTC)
+	q, err := cv.Quote(ctx, usd, "INR", at, Pricing{Spread: big.NewRat(15, 1000), Fee: fee, Validity: 30 * time.Second})
+	requirer.NoError(err)
+
+	asserter.Equal("USD/INR 83.1275", q.MidRate.String())
+	// 83.1275 × 0.985 = 81.8805875
+	asserter.Equal("USD/INR 81.8806", q.AppliedRate.String())
+	asserter.Equal("3/200", q.Spread.RatString())
+	asserter.Equal("1000.00", q.Source.StringWithoutSymbols())
+	asserter.Equal("81880.60", q.Target.StringWithoutSymbols())
+	asserter.Equal("INR", q.Target.Code)
+
+	total, err := q.Total()
+	requirer.NoError(err)
+	asserter.Equal("1002.50", total.StringWithoutSymbols())
+
+	asserter.Equal(at.Add(30*time.Second), q.ExpiresAt)
+	asserter.False(q.Expired(at.Add(30 * time.Second)))
+	asserter.True(q.Expired(at.Add(31 * time.Second)))
+
+	// the quote is independent of the source amount
+	usd.AddInt(1, 0)
+	asserter.Equal("1000.00", q.Source.StringWithoutSymbols())
+
+	// without spread & fee, the quote is the plain conversion
+	q, err = cv.Quote(ctx, usd, "INR", at, Pricing{})
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83.1275", q.AppliedRate.String())
+	asserter.Equal("83210.63", q.Target.StringWithoutSymbols())
+	asserter.Equal(0, q.Fee.FractionalTotal())
+	asserter.Equal("USD", q.Fee.Code)
+	asserter.True(q.ExpiresAt.IsZero())
+	asserter.False(q.Expired(at.Add(time.Hour)))
+
+	_, err = cv.Quote(ctx, usd, "INR", at, Pricing{Spread: big.NewRat(1, 1)})
+	asserter.ErrorIs(err, ErrInvalidRate)
+
+	_, err = cv.Quote(ctx, usd, "INR", at, Pricing{Spread: big.NewRat(-1, 100)})
+	asserter.ErrorIs(err, ErrInvalidRate)
+
+	inrFee, err := New(100, 0, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+	_, err = cv.Quote(ctx, usd, "INR", at, Pricing{Fee: inrFee})
+	me := &MismatchError{}
+	requirer.ErrorAs(err, &me)
+	asserter.Equal("Quote", me.Op)
+	asserter.Equal("INR", me.OtherCode)
+
+	_, err = cv.Quote(ctx, usd, "JPY", at, Pricing{})
+	asserter.ErrorIs(err, ErrRateNotFound)
+
+	_, err = cv.Quote(ctx, &Currency{}, "INR", at, Pricing{})
+	asserter.ErrorIs(err, ErrInvalidFUS)
+}
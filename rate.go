@@ -0,0 +1,122 @@
+package currency
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// BasisPoints returns a new instance of currency which is n basis points of c, i.e. n/10000,
+// computed exactly & rounded to the nearest fractional unit, halves away from zero.
+func (c *Currency) BasisPoints(n int) (*Currency, error) {
+	return c.scaled(big.NewRat(int64(n), 10000), RoundHalfUp)
+}
+
+// PerMille returns a new instance of currency which is n per mille of c, i.e. n/1000, computed
+// exactly & rounded to the nearest fractional unit, halves away from zero.
+func (c *Currency) PerMille(n int) (*Currency, error) {
+	return c.scaled(big.NewRat(int64(n), 1000), RoundHalfUp)
+}
+
+// Rate is an exact proportional rate, like a percentage, a number of basis points or per mille,
+// e.g. a fee of 1.75%. The zero value is a rate of 0.
+type Rate struct {
+	r *big.Rat
+}
+
+// NewRate returns a rate given as an exact fraction, e.g. 0.0175 for 1.75%.
+func NewRate(fraction *big.Rat) Rate {
+	if fraction == nil {
+		return Rate{}
+	}
+
+	return Rate{r: new(big.Rat).Set(fraction)}
+}
+
+// rateUnits are the suffixes accepted by ParseRate, with the number of units in 1
+var rateUnits = []struct {
+	suffix string
+	per    int64
+}{
+	{suffix: "%", per: 100},
+	{suffix: "‰", per: 1000},
+	{suffix: "bps", per: 10000},
+	{suffix: "bp", per: 10000},
+}
+
+// ParseRate parses a rate given as a percentage, per mille or basis points, e.g. "1.75%",
+// "17.5‰" or "175bp". A number without a suffix is a fraction, e.g. "0.0175".
+func ParseRate(s string) (Rate, error) {
+	s = strings.TrimSpace(s)
+	per := int64(1)
+	for _, u := range rateUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			s, per = strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), u.per
+			break
+		}
+	}
+
+	r, err := parseDecimal(s)
+	if err != nil {
+		return Rate{}, fmt.Errorf("%w: rate %q", ErrInvalidFactor, s)
+	}
+
+	return Rate{r: r.Quo(r, big.NewRat(per, 1))}, nil
+}
+
+// MustParseRate is the same as ParseRate, but panics if the rate is invalid. It's meant for
+// initializing package level variables, e.g. var gst = currency.MustParseRate("18%")
+func MustParseRate(s string) Rate {
+	r, err := ParseRate(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+// Rat returns the exact rate as a fraction, e.g. 0.0175 for 1.75%.
+func (rt Rate) Rat() *big.Rat {
+	if rt.r == nil {
+		return new(big.Rat)
+	}
+
+	return new(big.Rat).Set(rt.r)
+}
+
+// IsZero returns true if the rate is 0.
+func (rt Rate) IsZero() bool {
+	return rt.r == nil || rt.r.Sign() == 0
+}
+
+// Apply returns a new instance of currency which is c multiplied by the rate, computed exactly
+// & rounded once to the fractional unit using the rounding mode.
+func (rt Rate) Apply(c *Currency, mode RoundingMode) (*Currency, error) {
+	return c.scaled(rt.Rat(), mode)
+}
+
+// String returns the rate as a percentage, e.g. "1.75%".
+func (rt Rate) String() string {
+	return ratString(new(big.Rat).Mul(rt.Rat(), big.NewRat(100, 1))) + "%"
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding the rate as a percentage, e.g. "1.75%".
+func (rt Rate) MarshalText() ([]byte, error) {
+	s := rt.String()
+	if strings.Contains(s, "/") {
+		return nil, fmt.Errorf("%w: rate %s has no finite decimal representation", ErrInvalidFactor, s)
+	}
+
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting all the formats of ParseRate.
+func (rt *Rate) UnmarshalText(text []byte) error {
+	r, err := ParseRate(string(text))
+	if err != nil {
+		return err
+	}
+
+	*rt = r
+	return nil
+}
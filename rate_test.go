@@ -0,0 +1,126 @@
+package currency
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBasisPointsPerMille(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	cur, err := New(1234, 56, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+
+	// 123456 × 175 / 10000 = 2160.48
+	bp, err := cur.BasisPoints(175)
+	requirer.NoError(err)
+	asserter.Equal("21.60", bp.StringWithoutSymbols())
+
+	// 123456 × 175 / 1000 = 21604.8
+	pm, err := cur.PerMille(175)
+	requirer.NoError(err)
+	asserter.Equal("216.05", pm.StringWithoutSymbols())
+
+	// 50 × 1 / 1000 = 0.05, halves are rounded away from zero
+	half, err := NewFractional(-500, "INR", "₹", "paise", 100)
+	requirer.NoError(err)
+	pm, err = half.PerMille(1)
+	requirer.NoError(err)
+	asserter.Equal(-1, pm.FractionalTotal())
+	asserter.Equal(-500, half.FractionalTotal())
+
+	_, err = (&Currency{}).BasisPoints(1)
+	asserter.ErrorIs(err, ErrInvalidFUS)
+}
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "1.75%", want: "7/400"},
+		{in: "175bp", want: "7/400"},
+		{in: "175 bps", want: "7/400"},
+		{in: "17.5‰", want: "7/400"},
+		{in: "0.0175", want: "7/400"},
+		{in: " 18 % ", want: "9/50"},
+		{in: "-5%", want: "-1/20"},
+		{in: "0%", want: "0"},
+	}
+
+	for _, tt := range tests {
+		rt, err := ParseRate(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, rt.Rat().RatString(), tt.in)
+	}
+
+	for _, invalid := range []string{"", "%", "abc%", "1e2bp", "1.5pc"} {
+		_, err := ParseRate(invalid)
+		assert.ErrorIs(t, err, ErrInvalidFactor, invalid)
+	}
+
+	assert.Panics(t, func() { MustParseRate("x") })
+	assert.Equal(t, "18%", MustParseRate("18%").String())
+}
+
+func TestRate(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	cur, err := New(99, 99, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	rt := MustParseRate("175bp")
+	asserter.Equal("1.75%", rt.String())
+	asserter.False(rt.IsZero())
+
+	// 9999 × 0.0175 = 174.9825
+	fee, err := rt.Apply(cur, RoundHalfUp)
+	requirer.NoError(err)
+	asserter.Equal("1.75", fee.StringWithoutSymbols())
+
+	fee, err = rt.Apply(cur, RoundDown)
+	requirer.NoError(err)
+	asserter.Equal("1.74", fee.StringWithoutSymbols())
+
+	_, err = rt.Apply(cur, RoundingMode(99))
+	asserter.ErrorIs(err, ErrInvalidRoundingMode)
+
+	zero := Rate{}
+	asserter.True(zero.IsZero())
+	asserter.Equal("0%", zero.String())
+	fee, err = zero.Apply(cur, RoundHalfUp)
+	requirer.NoError(err)
+	asserter.Equal(0, fee.FractionalTotal())
+
+	// the rate is independent of the fraction it's created with
+	r := big.NewRat(1, 8)
+	rt = NewRate(r)
+	r.SetInt64(1)
+	asserter.Equal("12.5%", rt.String())
+	rt.Rat().SetInt64(2)
+	asserter.Equal("12.5%", rt.String())
+	asserter.True(NewRate(nil).IsZero())
+
+	type pricing struct {
+		Markup Rate `json:"markup"`
+	}
+
+	p := pricing{}
+	requirer.NoError(json.Unmarshal([]byte(`{"markup":"17.5‰"}`), &p))
+	asserter.Equal("1.75%", p.Markup.String())
+
+	data, err := json.Marshal(p)
+	requirer.NoError(err)
+	asserter.JSONEq(`{"markup":"1.75%"}`, string(data))
+
+	_, err = json.Marshal(pricing{Markup: NewRate(big.NewRat(1, 3))})
+	asserter.ErrorIs(err, ErrInvalidFactor)
+
+	asserter.ErrorIs(json.Unmarshal([]byte(`{"markup":"lots"}`), &p), ErrInvalidFactor)
+}
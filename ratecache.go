@@ -0,0 +1,314 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrStaleRate is the error returned by RateCache when the rate is older than the staleness limit
+var ErrStaleRate = errors.New("exchange rate is stale")
+
+// CacheHooks are the optional callbacks of RateCache, e.g. to record metrics. They are called
+// synchronously, and must be safe for concurrent use.
+type CacheHooks struct {
+	// Hit is called when a rate is served from the cache without a refresh
+	Hit func(base, quote string)
+	// Miss is called when a rate is missing or expired, before it's refreshed
+	Miss func(base, quote string)
+	// Refresh is called after a rate is fetched from the provider, with the time taken & the error if any
+	Refresh func(base, quote string, took time.Duration, err error)
+	// Stale is called when a rate is rejected for being older than the staleness limit
+	Stale func(base, quote string, age time.Duration)
+}
+
+// RateCache is a RateProvider which caches the rates of another provider. A cached rate is
+// served until its TTL expires, after which it's refreshed from the provider. Concurrent
+// requests for the same pair & time share a single refresh, which is not cancelled if a
+// request gives up, so that the rest of the requests are not affected.
+//
+// Rates are cached per pair & the requested time truncated to Resolution, e.g. per day, so
+// that historical rates (e.g. month end) are cached separately from the current rates.
+//
+// If a refresh fails, the expired rate is served as long as it's within MaxStaleness. A rate's
+// age is measured from its effective time, or from when it was fetched if it has no time, up to
+// the requested time or now, whichever is earlier.
+//
+// Whenever a rate is stored, the expired rates older than MaxStaleness are evicted, followed by
+// the least recently fetched rates if there are more than MaxEntries. The zero value is not
+// usable, Provider is required.
+type RateCache struct {
+	// Provider is the source of the rates
+	Provider RateProvider
+	// TTL is the duration for which a fetched rate is served without a refresh
+	TTL time.Duration
+	// PairTTL overrides TTL for specific pairs, keyed by "BASE/QUOTE", e.g. "USD/INR"
+	PairTTL map[string]time.Duration
+	// MaxStaleness is the maximum age of a rate served by the cache, including an expired rate
+	// served when its refresh fails. 0 means no limit.
+	MaxStaleness time.Duration
+	// MaxEntries is the maximum number of cached rates, 0 means 10000
+	MaxEntries int
+	// Resolution is the duration the requested time is truncated to for caching, 0 means a day
+	Resolution time.Duration
+	// RefreshTimeout is the maximum duration of a refresh from the provider, 0 means no limit
+	RefreshTimeout time.Duration
+	// Hooks are the optional callbacks, e.g. to record metrics
+	Hooks CacheHooks
+	// Now returns the current time, time.Now is used if nil
+	Now func() time.Time
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+	calls   map[cacheKey]*cacheCall
+}
+
+// cacheKey is a pair along with the requested time truncated to the resolution, in Unix seconds
+type cacheKey struct {
+	pair
+	at int64
+}
+
+// cacheEntry is a cached rate along with the time it was fetched
+type cacheEntry struct {
+	rate    ExchangeRate
+	fetched time.Time
+}
+
+// cacheCall is an in-flight refresh of a pair, shared by concurrent requests
+type cacheCall struct {
+	done chan struct{}
+	rate ExchangeRate
+	err  error
+}
+
+// Rate implements RateProvider, returning the cached rate from base to quote, refreshing it if
+// it's missing or expired.
+func (rc *RateCache) Rate(ctx context.Context, base, quote string, at time.Time) (ExchangeRate, error) {
+	if base == quote {
+		return ExchangeRate{Base: base, Quote: quote, Rate: big.NewRat(1, 1), Time: at}, nil
+	}
+
+	p := pair{base: base, quote: quote}
+	key := cacheKey{pair: p, at: at.Truncate(rc.resolution()).Unix()}
+
+	rc.mu.Lock()
+	entry, cached := rc.entries[key]
+	if cached && rc.now().Sub(entry.fetched) < rc.ttl(p) {
+		rc.mu.Unlock()
+		if rc.Hooks.Hit != nil {
+			rc.Hooks.Hit(base, quote)
+		}
+
+		return rc.checkAge(entry, at)
+	}
+
+	call, inflight := rc.calls[key]
+	if !inflight {
+		call = &cacheCall{done: make(chan struct{})}
+		if rc.calls == nil {
+			rc.calls = make(map[cacheKey]*cacheCall)
+		}
+		rc.calls[key] = call
+	}
+	rc.mu.Unlock()
+
+	if rc.Hooks.Miss != nil {
+		rc.Hooks.Miss(base, quote)
+	}
+
+	if !inflight {
+		go rc.refresh(detachedContext{parent: ctx}, key, at, call)
+	}
+
+	select {
+	case <-call.done:
+	case <-ctx.Done():
+		return ExchangeRate{}, ctx.Err()
+	}
+
+	if call.err == nil {
+		return rc.checkAge(cacheEntry{rate: call.rate, fetched: rc.now()}, at)
+	}
+
+	if cached {
+		er, err := rc.checkAge(entry, at)
+		if err == nil {
+			return er, nil
+		}
+	}
+
+	return ExchangeRate{}, call.err
+}
+
+// Invalidate removes the cached rates of the pair for all times, so that they're refreshed on
+// the next request.
+func (rc *RateCache) Invalidate(base, quote string) {
+	p := pair{base: base, quote: quote}
+
+	rc.mu.Lock()
+	for key := range rc.entries {
+		if key.pair == p {
+			delete(rc.entries, key)
+		}
+	}
+	rc.mu.Unlock()
+}
+
+// refresh fetches the rate from the provider, caches it if successful, and completes the call
+func (rc *RateCache) refresh(ctx context.Context, key cacheKey, at time.Time, call *cacheCall) {
+	if rc.RefreshTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rc.RefreshTimeout)
+		defer cancel()
+	}
+
+	start := rc.now()
+	call.rate, call.err = rc.Provider.Rate(ctx, key.base, key.quote, at)
+	end := rc.now()
+
+	if rc.Hooks.Refresh != nil {
+		rc.Hooks.Refresh(key.base, key.quote, end.Sub(start), call.err)
+	}
+
+	rc.mu.Lock()
+	if call.err == nil {
+		rc.store(key, cacheEntry{rate: call.rate, fetched: end})
+	}
+	delete(rc.calls, key)
+	rc.mu.Unlock()
+
+	close(call.done)
+}
+
+// store caches the entry, after evicting the expired entries older than MaxStaleness. If there
+// are still more than MaxEntries, the least recently fetched entries are evicted. It must be
+// called with mu held.
+func (rc *RateCache) store(key cacheKey, entry cacheEntry) {
+	if rc.entries == nil {
+		rc.entries = make(map[cacheKey]cacheEntry)
+	}
+
+	if rc.MaxStaleness > 0 {
+		for k, e := range rc.entries {
+			expired := entry.fetched.Sub(e.fetched) >= rc.ttl(k.pair)
+			if expired && rc.age(e, time.Unix(k.at, 0)) > rc.MaxStaleness {
+				delete(rc.entries, k)
+			}
+		}
+	}
+
+	delete(rc.entries, key)
+	if excess := len(rc.entries) + 1 - rc.maxEntries(); excess > 0 {
+		keys := make([]cacheKey, 0, len(rc.entries))
+		for k := range rc.entries {
+			keys = append(keys, k)
+		}
+
+		sort.Slice(keys, func(i, j int) bool {
+			return rc.entries[keys[i]].fetched.Before(rc.entries[keys[j]].fetched)
+		})
+
+		for _, k := range keys[:excess] {
+			delete(rc.entries, k)
+		}
+	}
+
+	rc.entries[key] = entry
+}
+
+// age returns the age of the entry as of the requested time or now, whichever is earlier
+func (rc *RateCache) age(entry cacheEntry, at time.Time) time.Duration {
+	since := entry.rate.Time
+	asOf := rc.now()
+	if since.IsZero() {
+		since = entry.fetched
+	} else if !at.IsZero() && at.Before(asOf) {
+		asOf = at
+	}
+
+	return asOf.Sub(since)
+}
+
+// checkAge returns the rate of the entry, or ErrStaleRate if it's older than MaxStaleness as
+// of the requested time or now, whichever is earlier
+func (rc *RateCache) checkAge(entry cacheEntry, at time.Time) (ExchangeRate, error) {
+	if rc.MaxStaleness <= 0 {
+		return entry.rate, nil
+	}
+
+	age := rc.age(entry, at)
+	if age <= rc.MaxStaleness {
+		return entry.rate, nil
+	}
+
+	if rc.Hooks.Stale != nil {
+		rc.Hooks.Stale(entry.rate.Base, entry.rate.Quote, age)
+	}
+
+	return ExchangeRate{}, fmt.Errorf(
+		"%w: %s/%s is %s old, limit %s",
+		ErrStaleRate, entry.rate.Base, entry.rate.Quote, age, rc.MaxStaleness,
+	)
+}
+
+// ttl returns the TTL of the pair
+func (rc *RateCache) ttl(p pair) time.Duration {
+	if ttl, ok := rc.PairTTL[p.base+"/"+p.quote]; ok {
+		return ttl
+	}
+
+	return rc.TTL
+}
+
+// maxEntries returns the maximum number of cached rates
+func (rc *RateCache) maxEntries() int {
+	if rc.MaxEntries > 0 {
+		return rc.MaxEntries
+	}
+
+	return 10000
+}
+
+// resolution returns the duration the requested time is truncated to
+func (rc *RateCache) resolution() time.Duration {
+	if rc.Resolution > 0 {
+		return rc.Resolution
+	}
+
+	return 24 * time.Hour
+}
+
+func (rc *RateCache) now() time.Time {
+	if rc.Now != nil {
+		return rc.Now()
+	}
+
+	return time.Now()
+}
+
+// detachedContext carries the values of its parent, but not its deadline or cancellation, so
+// that a refresh shared by several requests is not cancelled by any one of them
+type detachedContext struct {
+	parent context.Context
+}
+
+func (dc detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (dc detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (dc detachedContext) Err() error {
+	return nil
+}
+
+func (dc detachedContext) Value(key interface{}) interface{} {
+	return dc.parent.Value(key)
+}
@@ -0,0 +1,370 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a manually advanced clock
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (fc *fakeClock) Now() time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.now
+}
+
+func (fc *fakeClock) Advance(d time.Duration) {
+	fc.mu.Lock()
+	fc.now = fc.now.Add(d)
+	fc.mu.Unlock()
+}
+
+// countingProvider returns the rate set, counting the calls
+type countingProvider struct {
+	calls   int32
+	release chan struct{}
+
+	mu   sync.Mutex
+	rate ExchangeRate
+	err  error
+}
+
+func (cp *countingProvider) Rate(ctx context.Context, base, quote string, at time.Time) (ExchangeRate, error) {
+	atomic.AddInt32(&cp.calls, 1)
+	if cp.release != nil {
+		<-cp.release
+	}
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.rate, cp.err
+}
+
+func (cp *countingProvider) set(rate ExchangeRate, err error) {
+	cp.mu.Lock()
+	cp.rate, cp.err = rate, err
+	cp.mu.Unlock()
+}
+
+func TestRateCacheTTL(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2024, 3, 28, 10, 0, 0, 0, time.UTC)}
+
+	provider := &countingProvider{}
+	usdinr, err := NewExchangeRate("USD", "INR", "83.1275", time.Time{})
+	requirer.NoError(err)
+	provider.set(usdinr, nil)
+
+	hits, misses := 0, 0
+	rc := &RateCache{
+		Provider: provider,
+		TTL:      time.Minute,
+		PairTTL:  map[string]time.Duration{"EUR/USD": time.Hour},
+		Now:      clock.Now,
+		Hooks: CacheHooks{
+			Hit:  func(base, quote string) { hits++ },
+			Miss: func(base, quote string) { misses++ },
+		},
+	}
+
+	er, err := rc.Rate(ctx, "USD", "INR", clock.Now())
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83.1275", er.String())
+
+	clock.Advance(59 * time.Second)
+	_, err = rc.Rate(ctx, "USD", "INR", clock.Now())
+	requirer.NoError(err)
+	asserter.EqualValues(1, atomic.LoadInt32(&provider.calls))
+
+	usdinr, err = NewExchangeRate("USD", "INR", "83.20", time.Time{})
+	requirer.NoError(err)
+	provider.set(usdinr, nil)
+
+	clock.Advance(time.Second)
+	er, err = rc.Rate(ctx, "USD", "INR", clock.Now())
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83.2", er.String())
+	asserter.EqualValues(2, atomic.LoadInt32(&provider.calls))
+	asserter.Equal(1, hits)
+	asserter.Equal(2, misses)
+
+	// pair specific TTL
+	eurusd, err := NewExchangeRate("EUR", "USD", "1.0811", time.Time{})
+	requirer.NoError(err)
+	provider.set(eurusd, nil)
+
+	_, err = rc.Rate(ctx, "EUR", "USD", clock.Now())
+	requirer.NoError(err)
+	clock.Advance(30 * time.Minute)
+	_, err = rc.Rate(ctx, "EUR", "USD", clock.Now())
+	requirer.NoError(err)
+	asserter.EqualValues(3, atomic.LoadInt32(&provider.calls))
+
+	rc.Invalidate("EUR", "USD")
+	_, err = rc.Rate(ctx, "EUR", "USD", clock.Now())
+	requirer.NoError(err)
+	asserter.EqualValues(4, atomic.LoadInt32(&provider.calls))
+
+	er, err = rc.Rate(ctx, "JPY", "JPY", clock.Now())
+	requirer.NoError(err)
+	asserter.Equal("JPY/JPY 1", er.String())
+	asserter.EqualValues(4, atomic.LoadInt32(&provider.calls))
+}
+
+func TestRateCacheStaleness(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2024, 3, 28, 10, 0, 0, 0, time.UTC)}
+	errSource := errors.New("rate source unavailable")
+
+	provider := &countingProvider{}
+	usdinr, err := NewExchangeRate("USD", "INR", "83.1275", clock.Now())
+	requirer.NoError(err)
+	provider.set(usdinr, nil)
+
+	stale := []time.Duration{}
+	refreshErrs := []error{}
+	rc := &RateCache{
+		Provider:     provider,
+		TTL:          time.Minute,
+		MaxStaleness: 10 * time.Minute,
+		Now:          clock.Now,
+		Hooks: CacheHooks{
+			Refresh: func(base, quote string, took time.Duration, err error) { refreshErrs = append(refreshErrs, err) },
+			Stale:   func(base, quote string, age time.Duration) { stale = append(stale, age) },
+		},
+	}
+
+	_, err = rc.Rate(ctx, "USD", "INR", clock.Now())
+	requirer.NoError(err)
+
+	// refresh fails, the expired rate is served within the staleness limit
+	provider.set(ExchangeRate{}, errSource)
+	clock.Advance(5 * time.Minute)
+	er, err := rc.Rate(ctx, "USD", "INR", clock.Now())
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83.1275", er.String())
+
+	clock.Advance(6 * time.Minute)
+	_, err = rc.Rate(ctx, "USD", "INR", clock.Now())
+	asserter.ErrorIs(err, errSource)
+	asserter.Equal([]time.Duration{11 * time.Minute}, stale)
+	asserter.Equal([]error{nil, errSource, errSource}, refreshErrs)
+
+	// the provider returns a rate which is already too old
+	old, err := NewExchangeRate("USD", "INR", "83.00", clock.Now().Add(-time.Hour))
+	requirer.NoError(err)
+	provider.set(old, nil)
+	_, err = rc.Rate(ctx, "USD", "INR", clock.Now())
+	asserter.ErrorIs(err, ErrStaleRate)
+
+	// without a staleness limit, an expired rate is served however old if the refresh fails
+	rc.MaxStaleness = 0
+	_, err = rc.Rate(ctx, "USD", "INR", clock.Now())
+	requirer.NoError(err)
+	provider.set(ExchangeRate{}, errSource)
+	clock.Advance(10 * time.Hour)
+	er, err = rc.Rate(ctx, "USD", "INR", clock.Now())
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83", er.String())
+}
+
+func TestRateCacheEviction(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2024, 3, 28, 10, 0, 0, 0, time.UTC)}
+
+	provider := &countingProvider{}
+	usdinr, err := NewExchangeRate("USD", "INR", "83.1275", time.Time{})
+	requirer.NoError(err)
+	provider.set(usdinr, nil)
+
+	rc := &RateCache{
+		Provider:     provider,
+		TTL:          time.Minute,
+		MaxStaleness: time.Hour,
+		Resolution:   time.Minute,
+		Now:          clock.Now,
+	}
+
+	// a long running process caches a rate per resolution, only the rates within the
+	// staleness limit are retained
+	for i := 0; i < 180; i++ {
+		_, err = rc.Rate(ctx, "USD", "INR", clock.Now())
+		requirer.NoError(err)
+		clock.Advance(time.Minute)
+	}
+	asserter.Len(rc.entries, 61)
+
+	// without a staleness limit, the number of rates is capped
+	rc.MaxStaleness = 0
+	rc.MaxEntries = 10
+	for i := 0; i < 20; i++ {
+		_, err = rc.Rate(ctx, "USD", "INR", clock.Now())
+		requirer.NoError(err)
+		clock.Advance(time.Minute)
+	}
+	asserter.Len(rc.entries, 10)
+
+	latest := cacheKey{pair: pair{base: "USD", quote: "INR"}, at: clock.Now().Add(-time.Minute).Unix()}
+	asserter.Contains(rc.entries, latest)
+}
+
+func TestRateCacheSingleFlight(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	const concurrency = 20
+
+	provider := &countingProvider{release: make(chan struct{})}
+	usdinr, err := NewExchangeRate("USD", "INR", "83.1275", time.Time{})
+	requirer.NoError(err)
+	provider.set(usdinr, nil)
+
+	var misses int32
+	joined := make(chan struct{})
+	rc := &RateCache{
+		Provider: provider,
+		TTL:      time.Minute,
+		Hooks: CacheHooks{
+			Miss: func(base, quote string) {
+				if atomic.AddInt32(&misses, 1) == concurrency {
+					close(joined)
+				}
+			},
+		},
+	}
+
+	wg := sync.WaitGroup{}
+	results := make([]string, concurrency)
+	errs := make([]error, concurrency)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			er, err := rc.Rate(context.Background(), "USD", "INR", time.Now())
+			results[i], errs[i] = er.String(), err
+		}(i)
+	}
+
+	// release the provider only after all the requests have joined the refresh
+	<-joined
+	close(provider.release)
+	wg.Wait()
+
+	asserter.EqualValues(1, atomic.LoadInt32(&provider.calls))
+	for i := range results {
+		asserter.NoError(errs[i])
+		asserter.Equal("USD/INR 83.1275", results[i])
+	}
+}
+
+func TestRateCacheContext(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	provider := &countingProvider{release: make(chan struct{})}
+	usdinr, err := NewExchangeRate("USD", "INR", "83.1275", time.Time{})
+	requirer.NoError(err)
+	provider.set(usdinr, nil)
+
+	rc := &RateCache{Provider: provider, TTL: time.Minute}
+	at := time.Date(2024, 3, 28, 10, 0, 0, 0, time.UTC)
+
+	// the first request starts the refresh & gives up
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := rc.Rate(ctx, "USD", "INR", at)
+		first <- err
+	}()
+
+	for atomic.LoadInt32(&provider.calls) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	second := make(chan error)
+	go func() {
+		er, err := rc.Rate(context.Background(), "USD", "INR", at)
+		if err == nil {
+			asserter.Equal("USD/INR 83.1275", er.String())
+		}
+		second <- err
+	}()
+
+	cancel()
+	asserter.ErrorIs(<-first, context.Canceled)
+
+	// the shared refresh is not cancelled for the rest of the requests
+	close(provider.release)
+	asserter.NoError(<-second)
+	asserter.EqualValues(1, atomic.LoadInt32(&provider.calls))
+
+	// a cancelled request doesn't wait for the refresh
+	rc.Invalidate("USD", "INR")
+	provider.release = make(chan struct{})
+	defer close(provider.release)
+	_, err = rc.Rate(ctx, "USD", "INR", at)
+	asserter.ErrorIs(err, context.Canceled)
+}
+
+func TestRateCacheHistorical(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	ctx := context.Background()
+	rs := NewRateStore(FallbackPrevious)
+	err := rs.LoadCSV(strings.NewReader(`base,quote,rate,time
+USD,INR,82.90,2024-01-31
+USD,INR,83.00,2024-03-28
+`))
+	requirer.NoError(err)
+
+	rc := &RateCache{Provider: rs, TTL: time.Hour}
+
+	er, err := rc.Rate(ctx, "USD", "INR", time.Date(2024, 3, 28, 10, 0, 0, 0, time.UTC))
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83", er.String())
+
+	er, err = rc.Rate(ctx, "USD", "INR", time.Date(2024, 1, 31, 18, 0, 0, 0, time.UTC))
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 82.9", er.String())
+
+	// cached per day
+	hits := 0
+	rc.Hooks.Hit = func(base, quote string) { hits++ }
+	er, err = rc.Rate(ctx, "USD", "INR", time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC))
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 82.9", er.String())
+	er, err = rc.Rate(ctx, "USD", "INR", time.Date(2024, 3, 28, 23, 0, 0, 0, time.UTC))
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83", er.String())
+	asserter.Equal(2, hits)
+
+	// the age of a historical rate is as of the requested time
+	rc.MaxStaleness = 24 * time.Hour
+	rc.Invalidate("USD", "INR")
+	_, err = rc.Rate(ctx, "USD", "INR", time.Date(2024, 1, 31, 18, 0, 0, 0, time.UTC))
+	requirer.NoError(err)
+	_, err = rc.Rate(ctx, "USD", "INR", time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC))
+	asserter.ErrorIs(err, ErrStaleRate)
+}
@@ -0,0 +1,252 @@
+package currency
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// jsonRates is the JSON format of rates, e.g. {"base":"EUR","date":"2024-03-28","rates":{"USD":"1.0811"}}
+type jsonRates struct {
+	Base  string                 `json:"base"`
+	Date  string                 `json:"date"`
+	Rates map[string]json.Number `json:"rates"`
+}
+
+// LoadRatesJSON loads the rates from a JSON document of the form
+//
+//	{"base": "EUR", "date": "2024-03-28", "rates": {"USD": "1.0811", "INR": 90.1245}}
+//
+// Rates can be either strings or numbers, and are parsed exactly. The date is optional, and can
+// be a date or an RFC 3339 timestamp.
+func LoadRatesJSON(r io.Reader) (*RateTable, error) {
+	jr := jsonRates{}
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	err := dec.Decode(&jr)
+	if err != nil {
+		return nil, err
+	}
+
+	at, err := parseRateTime(jr.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	rt, _ := NewRateTable()
+	for quote, rate := range jr.Rates {
+		er, err := NewExchangeRate(jr.Base, quote, rate.String(), at)
+		if err != nil {
+			return nil, err
+		}
+		_ = rt.Set(er)
+	}
+
+	return rt, nil
+}
+
+// LoadRatesCSV loads the rates from CSV with a header row & the columns base, quote, rate and
+// an optional time, e.g.
+//
+//	base,quote,rate,time
+//	USD,INR,83.1275,2024-03-28
+//
+// The rate is either a decimal, or an exact fraction like 1/3. If a pair is repeated, the rate
+// with the latest time is used.
+func LoadRatesCSV(r io.Reader) (*RateTable, error) {
+	rt, _ := NewRateTable()
+	err := readRatesCSV(r, rt.setLatest)
+	if err != nil {
+		return nil, err
+	}
+
+	return rt, nil
+}
+
+// csvRate returns the exchange rate of a CSV record, where the rate is a decimal or a fraction of
+// decimals, e.g. 10000/10811 as written by RateStore.WriteCSV for exact rates
+func csvRate(base, quote, rate string, at time.Time) (ExchangeRate, error) {
+	parts := strings.Split(rate, "/")
+	if len(parts) == 1 {
+		return NewExchangeRate(base, quote, rate, at)
+	}
+
+	if len(parts) != 2 {
+		return ExchangeRate{}, fmt.Errorf("%w: %q", ErrInvalidRate, rate)
+	}
+
+	num, err := parseDecimal(parts[0])
+	if err != nil {
+		return ExchangeRate{}, fmt.Errorf("%w: %q", ErrInvalidRate, rate)
+	}
+
+	denom, err := parseDecimal(parts[1])
+	if err != nil || denom.Sign() == 0 {
+		return ExchangeRate{}, fmt.Errorf("%w: %q", ErrInvalidRate, rate)
+	}
+
+	er := ExchangeRate{Base: base, Quote: quote, Rate: num.Quo(num, denom), Time: at}
+	err = er.Validate()
+	if err != nil {
+		return ExchangeRate{}, err
+	}
+
+	return er, nil
+}
+
+// readRatesCSV reads all the rates from CSV, calling fn for every rate
+func readRatesCSV(r io.Reader, fn func(er ExchangeRate) error) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return err
+	}
+
+	cols := map[string]int{"time": -1}
+	for idx, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = idx
+	}
+
+	for _, name := range []string{"base", "quote", "rate"} {
+		if _, ok := cols[name]; !ok {
+			return fmt.Errorf("%w: CSV column %q missing", ErrInvalidRate, name)
+		}
+	}
+
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		field := func(name string) string {
+			idx := cols[name]
+			if idx < 0 || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+
+		at, err := parseRateTime(field("time"))
+		if err != nil {
+			return err
+		}
+
+		er, err := csvRate(field("base"), field("quote"), field("rate"), at)
+		if err != nil {
+			return err
+		}
+
+		err = fn(er)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ecbEnvelope is the eurofxref XML format published by the European Central Bank
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// LoadRatesECB loads the EUR based rates from the European Central Bank's eurofxref XML format,
+// e.g. eurofxref-daily.xml. If the document has rates of multiple days (eurofxref-hist.xml), the
+// latest rate of every currency is used.
+func LoadRatesECB(r io.Reader) (*RateTable, error) {
+	rt, _ := NewRateTable()
+	err := readRatesECB(r, rt.setLatest)
+	if err != nil {
+		return nil, err
+	}
+
+	return rt, nil
+}
+
+// readRatesECB reads all the rates from the eurofxref XML format, calling fn for every rate
+func readRatesECB(r io.Reader, fn func(er ExchangeRate) error) error {
+	env := ecbEnvelope{}
+	err := xml.NewDecoder(r).Decode(&env)
+	if err != nil {
+		return err
+	}
+
+	for _, day := range env.Days {
+		at, err := parseRateTime(day.Time)
+		if err != nil {
+			return err
+		}
+
+		for _, rate := range day.Rates {
+			er, err := NewExchangeRate("EUR", rate.Currency, rate.Rate, at)
+			if err != nil {
+				return err
+			}
+
+			err = fn(er)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadRatesFile loads the rates from a local file, the format is chosen based on the file
+// extension. i.e. .json, .csv or .xml (ECB eurofxref).
+func LoadRatesFile(path string) (*RateTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return LoadRatesJSON(f)
+	case ".csv":
+		return LoadRatesCSV(f)
+	case ".xml":
+		return LoadRatesECB(f)
+	}
+
+	return nil, fmt.Errorf("unsupported rates file format %q", filepath.Ext(path))
+}
+
+// parseRateTime parses a date (2006-01-02) or an RFC 3339 timestamp. An empty string is the zero time.
+func parseRateTime(str string) (time.Time, error) {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse("2006-01-02", str); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid time %q", ErrInvalidRate, str)
+	}
+
+	return t, nil
+}
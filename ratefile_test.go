@@ -0,0 +1,120 @@
+package currency
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadRatesFile(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	ctx := context.Background()
+	day := time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC)
+
+	list := []struct {
+		File     string
+		Expected map[string]string
+		Time     map[string]time.Time
+	}{
+		{
+			File: "rates.json",
+			Expected: map[string]string{
+				"EUR/USD": "EUR/USD 1.0811",
+				"EUR/INR": "EUR/INR 90.1245",
+				"JPY/EUR": "JPY/EUR 20/3269",
+			},
+			Time: map[string]time.Time{"EUR/USD": day},
+		},
+		{
+			File: "rates.csv",
+			Expected: map[string]string{
+				"USD/INR": "USD/INR 83.1275",
+				"EUR/USD": "EUR/USD 1.0811",
+				"GBP/USD": "GBP/USD 1.2623",
+			},
+			Time: map[string]time.Time{
+				"USD/INR": day,
+				"EUR/USD": day.Add(16 * time.Hour),
+				"GBP/USD": {},
+			},
+		},
+		{
+			File: "eurofxref-daily.xml",
+			Expected: map[string]string{
+				"EUR/USD": "EUR/USD 1.0811",
+				"EUR/GBP": "EUR/GBP 0.85525",
+				"EUR/CHF": "EUR/CHF 0.9802",
+			},
+			Time: map[string]time.Time{
+				"EUR/USD": day,
+				"EUR/CHF": day.AddDate(0, 0, -1),
+			},
+		},
+	}
+
+	for _, l := range list {
+		rt, err := LoadRatesFile(filepath.Join("testdata", l.File))
+		requirer.NoError(err, l.File)
+
+		for p, expected := range l.Expected {
+			codes := strings.Split(p, "/")
+			er, err := rt.Rate(ctx, codes[0], codes[1], time.Now())
+			if asserter.NoError(err, l.File+" "+p) {
+				asserter.Equal(expected, er.String(), l.File)
+			}
+
+			if at, ok := l.Time[p]; ok {
+				asserter.True(at.Equal(er.Time), l.File+" "+p)
+			}
+		}
+	}
+
+	_, err := LoadRatesFile(filepath.Join("testdata", "missing.json"))
+	asserter.Error(err)
+
+	_, err = LoadRatesFile(filepath.Join("testdata", "binary_v1_usd.golden"))
+	asserter.Error(err)
+}
+
+func TestLoadRatesErrors(t *testing.T) {
+	asserter := assert.New(t)
+
+	_, err := LoadRatesJSON(strings.NewReader(`{"base":"EUR","rates":{"USD":"-1"}}`))
+	asserter.ErrorIs(err, ErrInvalidRate)
+
+	_, err = LoadRatesJSON(strings.NewReader(`{"base":"EUR","date":"28/03/2024","rates":{}}`))
+	asserter.ErrorIs(err, ErrInvalidRate)
+
+	_, err = LoadRatesJSON(strings.NewReader(`{"base":"EUR","rates":{"USD":"abc"}}`))
+	asserter.Error(err)
+
+	_, err = LoadRatesCSV(strings.NewReader("base,rate\nUSD,1"))
+	asserter.ErrorIs(err, ErrInvalidRate)
+
+	_, err = LoadRatesCSV(strings.NewReader("base,quote,rate\nUSD,INR,0"))
+	asserter.ErrorIs(err, ErrInvalidRate)
+
+	for _, rate := range []string{"1/0", "0x10/3", "1/2/3", "1/", "1e3/2"} {
+		_, err = LoadRatesCSV(strings.NewReader("base,quote,rate\nUSD,INR," + rate))
+		asserter.ErrorIs(err, ErrInvalidRate, rate)
+	}
+
+	_, err = LoadRatesCSV(strings.NewReader(""))
+	asserter.Error(err)
+
+	_, err = LoadRatesECB(strings.NewReader(`<Envelope><Cube><Cube time="2024-03-28"><Cube currency="USD" rate="x"/></Cube></Cube></Envelope>`))
+	asserter.ErrorIs(err, ErrInvalidRate)
+
+	_, err = LoadRatesECB(strings.NewReader(`<Envelope><Cube><Cube time="yesterday"></Cube></Cube></Envelope>`))
+	asserter.ErrorIs(err, ErrInvalidRate)
+
+	_, err = LoadRatesECB(strings.NewReader(`<Envelope>`))
+	asserter.Error(err)
+}
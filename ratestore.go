@@ -0,0 +1,251 @@
+package currency
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FallbackPolicy decides which rate is used by RateStore when there's no rate effective on the
+// day of the requested time.
+type FallbackPolicy int
+
+const (
+	// FallbackPrevious uses the latest rate at or before the requested time, however old it is
+	FallbackPrevious FallbackPolicy = iota
+	// FallbackPreviousBusinessDay uses the latest rate at or before the requested time, only if it
+	// is effective on the same day or the previous business day (Monday to Friday)
+	FallbackPreviousBusinessDay
+	// FallbackError requires a rate effective on the same day as the requested time
+	FallbackError
+)
+
+// RateStore is an in-memory time series of exchange rates, keyed by currency pair & effective
+// time. It implements RateProvider with as-of lookups, i.e. the latest rate at or before the
+// requested time, subject to the fallback policy. A pair's series is also used for the inverse
+// pair, if the inverse has no rate at or before the requested time. The rates are copied when added & returned, so they can't
+// be modified by the callers.
+type RateStore struct {
+	// Fallback is the policy used when there's no rate effective on the day of the requested time
+	Fallback FallbackPolicy
+
+	mu     sync.RWMutex
+	series map[pair][]ExchangeRate
+}
+
+// NewRateStore returns a new empty rate store with the given fallback policy.
+func NewRateStore(fallback FallbackPolicy) *RateStore {
+	return &RateStore{
+		Fallback: fallback,
+		series:   make(map[pair][]ExchangeRate),
+	}
+}
+
+// Add adds rates to the store. A rate replaces an existing rate of the same pair & time.
+func (rs *RateStore) Add(rates ...ExchangeRate) error {
+	for _, er := range rates {
+		err := er.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if rs.series == nil {
+		rs.series = make(map[pair][]ExchangeRate)
+	}
+
+	for _, er := range rates {
+		er = er.clone()
+		p := pair{base: er.Base, quote: er.Quote}
+		series := rs.series[p]
+
+		idx := sort.Search(len(series), func(i int) bool {
+			return !series[i].Time.Before(er.Time)
+		})
+
+		if idx < len(series) && series[idx].Time.Equal(er.Time) {
+			series[idx] = er
+			continue
+		}
+
+		series = append(series, ExchangeRate{})
+		copy(series[idx+1:], series[idx:])
+		series[idx] = er
+		rs.series[p] = series
+	}
+
+	return nil
+}
+
+// LoadCSV adds all the rates from CSV to the store, in the same format as LoadRatesCSV.
+func (rs *RateStore) LoadCSV(r io.Reader) error {
+	rates := []ExchangeRate{}
+	err := readRatesCSV(r, func(er ExchangeRate) error {
+		rates = append(rates, er)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return rs.Add(rates...)
+}
+
+// WriteCSV writes all the rates in the store as CSV, in the format read by LoadCSV.
+func (rs *RateStore) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write([]string{"base", "quote", "rate", "time"})
+	if err != nil {
+		return err
+	}
+
+	for _, er := range rs.Rates() {
+		err = cw.Write([]string{er.Base, er.Quote, ratString(er.Rate), er.Time.Format(time.RFC3339Nano)})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// Snapshot returns an independent copy of the store, which is unaffected by rates added later.
+func (rs *RateStore) Snapshot() *RateStore {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	snap := NewRateStore(rs.Fallback)
+	for p, series := range rs.series {
+		snap.series[p] = append([]ExchangeRate(nil), series...)
+	}
+
+	return snap
+}
+
+// Rates implements RateLister, returning all the rates in the store sorted by pair & time.
+func (rs *RateStore) Rates() []ExchangeRate {
+	rs.mu.RLock()
+	rates := []ExchangeRate{}
+	for _, series := range rs.series {
+		for _, er := range series {
+			rates = append(rates, er.clone())
+		}
+	}
+	rs.mu.RUnlock()
+
+	sort.SliceStable(rates, func(i, j int) bool {
+		if rates[i].Base != rates[j].Base {
+			return rates[i].Base < rates[j].Base
+		}
+
+		if rates[i].Quote != rates[j].Quote {
+			return rates[i].Quote < rates[j].Quote
+		}
+
+		return rates[i].Time.Before(rates[j].Time)
+	})
+
+	return rates
+}
+
+// Rate implements RateProvider, returning the latest rate at or before `at`, subject to the fallback policy.
+func (rs *RateStore) Rate(ctx context.Context, base, quote string, at time.Time) (ExchangeRate, error) {
+	if base == quote {
+		return ExchangeRate{Base: base, Quote: quote, Rate: big.NewRat(1, 1), Time: at}, nil
+	}
+
+	er, ok, found := rs.asOf(base, quote, at)
+	if !found {
+		return ExchangeRate{}, fmt.Errorf("%w: %s/%s", ErrRateNotFound, base, quote)
+	}
+
+	if !ok {
+		return ExchangeRate{}, fmt.Errorf("%w: %s/%s, no rate at or before %s", ErrRateNotFound, base, quote, at.Format(time.RFC3339))
+	}
+
+	if !rs.acceptable(er.Time, at) {
+		return ExchangeRate{}, fmt.Errorf(
+			"%w: %s/%s, latest rate at %s is too old for %s",
+			ErrRateNotFound, base, quote, er.Time.Format(time.RFC3339), at.Format(time.RFC3339),
+		)
+	}
+
+	return er, nil
+}
+
+// acceptable returns true if a rate effective at `effective` can be used for `at`, as per the fallback policy
+func (rs *RateStore) acceptable(effective, at time.Time) bool {
+	day := truncateDay(at)
+	effDay := truncateDay(effective.In(at.Location()))
+
+	switch rs.Fallback {
+	case FallbackError:
+		return effDay.Equal(day)
+	case FallbackPreviousBusinessDay:
+		return !effDay.Before(previousBusinessDay(day))
+	}
+
+	return true
+}
+
+// asOf returns the latest rate of the pair at or before `at`, using the inverse pair if the pair
+// has no rate at or before `at`. found is false if neither pair has any rates.
+func (rs *RateStore) asOf(base, quote string, at time.Time) (er ExchangeRate, ok bool, found bool) {
+	rs.mu.RLock()
+	defer rs.mu.RUnlock()
+
+	if series, exists := rs.series[pair{base: base, quote: quote}]; exists {
+		found = true
+		er, ok = seriesAsOf(series, at)
+		if ok {
+			return er.clone(), true, true
+		}
+	}
+
+	if series, exists := rs.series[pair{base: quote, quote: base}]; exists {
+		found = true
+		er, ok = seriesAsOf(series, at)
+		if ok {
+			return er.Inverse(), true, true
+		}
+	}
+
+	return ExchangeRate{}, false, found
+}
+
+// seriesAsOf returns the latest rate at or before `at` from the series sorted by time
+func seriesAsOf(series []ExchangeRate, at time.Time) (ExchangeRate, bool) {
+	idx := sort.Search(len(series), func(i int) bool {
+		return series[i].Time.After(at)
+	})
+
+	if idx == 0 {
+		return ExchangeRate{}, false
+	}
+
+	return series[idx-1], true
+}
+
+// truncateDay returns the start of the day of t, in the location of t
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// previousBusinessDay returns the start of the weekday before the day of t
+func previousBusinessDay(t time.Time) time.Time {
+	day := truncateDay(t).AddDate(0, 0, -1)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, -1)
+	}
+
+	return day
+}
@@ -0,0 +1,261 @@
+package currency
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRateStore(t *testing.T, fallback FallbackPolicy) *RateStore {
+	rs := NewRateStore(fallback)
+	err := rs.LoadCSV(strings.NewReader(`base,quote,rate,time
+USD,INR,83.00,2024-03-21
+USD,INR,83.10,2024-03-22
+USD,INR,83.25,2024-03-26
+EUR,USD,1.0811,2024-03-22
+`))
+	require.NoError(t, err)
+
+	return rs
+}
+
+func TestRateStoreAsOf(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	ctx := context.Background()
+	rs := newTestRateStore(t, FallbackPrevious)
+
+	// Monday, the latest rate is of the previous Friday
+	er, err := rs.Rate(ctx, "USD", "INR", time.Date(2024, 3, 25, 10, 0, 0, 0, time.UTC))
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83.1", er.String())
+	asserter.True(er.Time.Equal(time.Date(2024, 3, 22, 0, 0, 0, 0, time.UTC)))
+
+	// effective exactly at the requested time
+	er, err = rs.Rate(ctx, "USD", "INR", time.Date(2024, 3, 26, 0, 0, 0, 0, time.UTC))
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83.25", er.String())
+
+	er, err = rs.Rate(ctx, "USD", "INR", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83.25", er.String())
+
+	// inverse series
+	er, err = rs.Rate(ctx, "USD", "EUR", time.Date(2024, 3, 22, 12, 0, 0, 0, time.UTC))
+	requirer.NoError(err)
+	asserter.Equal("USD/EUR 10000/10811", er.String())
+
+	er, err = rs.Rate(ctx, "JPY", "JPY", time.Time{})
+	requirer.NoError(err)
+	asserter.Equal("JPY/JPY 1", er.String())
+
+	_, err = rs.Rate(ctx, "USD", "INR", time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC))
+	asserter.ErrorIs(err, ErrRateNotFound)
+
+	_, err = rs.Rate(ctx, "USD", "JPY", time.Date(2024, 3, 22, 0, 0, 0, 0, time.UTC))
+	asserter.ErrorIs(err, ErrRateNotFound)
+
+	// the inverse series is used when the pair has no rate at or before the requested time
+	requirer.NoError(rs.Add(ExchangeRate{Base: "INR", Quote: "USD", Rate: big.NewRat(1, 80), Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}))
+	er, err = rs.Rate(ctx, "USD", "INR", time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC))
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 80", er.String())
+
+	er, err = rs.Rate(ctx, "USD", "INR", time.Date(2024, 3, 21, 0, 0, 0, 0, time.UTC))
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83", er.String())
+
+	_, err = rs.Rate(ctx, "USD", "INR", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	asserter.ErrorIs(err, ErrRateNotFound)
+}
+
+func TestRateStoreCopies(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	ctx := context.Background()
+	at := time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC)
+	usdinr, err := NewExchangeRate("USD", "INR", "83.1275", at)
+	requirer.NoError(err)
+
+	rs := NewRateStore(FallbackPrevious)
+	requirer.NoError(rs.Add(usdinr))
+
+	// neither the rate added, nor the rates returned share the rate of the store
+	usdinr.Rate.SetInt64(1)
+	er, err := rs.Rate(ctx, "USD", "INR", at)
+	requirer.NoError(err)
+	er.Rate.SetInt64(2)
+	rs.Rates()[0].Rate.SetInt64(3)
+
+	er, err = rs.Rate(ctx, "USD", "INR", at)
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83.1275", er.String())
+}
+
+func TestRateStoreFallback(t *testing.T) {
+	ctx := context.Background()
+
+	// EUR/USD only has a rate on Friday, 2024-03-22
+	tests := []struct {
+		name     string
+		fallback FallbackPolicy
+		at       time.Time
+		want     string
+	}{
+		{
+			name:     "error, same day",
+			fallback: FallbackError,
+			at:       time.Date(2024, 3, 22, 18, 0, 0, 0, time.UTC),
+			want:     "EUR/USD 1.0811",
+		},
+		{
+			name:     "error, previous day",
+			fallback: FallbackError,
+			at:       time.Date(2024, 3, 23, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "previous business day, monday uses friday",
+			fallback: FallbackPreviousBusinessDay,
+			at:       time.Date(2024, 3, 25, 9, 0, 0, 0, time.UTC),
+			want:     "EUR/USD 1.0811",
+		},
+		{
+			name:     "previous business day, sunday uses friday",
+			fallback: FallbackPreviousBusinessDay,
+			at:       time.Date(2024, 3, 24, 9, 0, 0, 0, time.UTC),
+			want:     "EUR/USD 1.0811",
+		},
+		{
+			name:     "previous business day, tuesday does not use friday",
+			fallback: FallbackPreviousBusinessDay,
+			at:       time.Date(2024, 3, 26, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "previous, any age",
+			fallback: FallbackPrevious,
+			at:       time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+			want:     "EUR/USD 1.0811",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := newTestRateStore(t, tt.fallback)
+			er, err := rs.Rate(ctx, "EUR", "USD", tt.at)
+			if tt.want == "" {
+				assert.ErrorIs(t, err, ErrRateNotFound)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, er.String())
+		})
+	}
+}
+
+func TestRateStoreAdd(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	day := func(d int) time.Time {
+		return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	rs := &RateStore{}
+	requirer.NoError(rs.Add(
+		ExchangeRate{Base: "USD", Quote: "INR", Rate: big.NewRat(83, 1), Time: day(3)},
+		ExchangeRate{Base: "USD", Quote: "INR", Rate: big.NewRat(82, 1), Time: day(1)},
+		ExchangeRate{Base: "USD", Quote: "INR", Rate: big.NewRat(84, 1), Time: day(2)},
+		ExchangeRate{Base: "EUR", Quote: "USD", Rate: big.NewRat(11, 10), Time: day(2)},
+	))
+
+	// replaces the rate of the same time
+	requirer.NoError(rs.Add(ExchangeRate{Base: "USD", Quote: "INR", Rate: big.NewRat(85, 1), Time: day(2)}))
+
+	asserter.ErrorIs(rs.Add(ExchangeRate{Base: "USD", Quote: "INR", Rate: big.NewRat(-1, 1), Time: day(4)}), ErrInvalidRate)
+
+	got := []string{}
+	for _, er := range rs.Rates() {
+		got = append(got, er.String()+" "+er.Time.Format("2006-01-02"))
+	}
+	asserter.Equal([]string{
+		"EUR/USD 1.1 2024-03-02",
+		"USD/INR 82 2024-03-01",
+		"USD/INR 85 2024-03-02",
+		"USD/INR 83 2024-03-03",
+	}, got)
+}
+
+func TestRateStoreSnapshot(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	ctx := context.Background()
+	rs := newTestRateStore(t, FallbackPrevious)
+	snap := rs.Snapshot()
+
+	at := time.Date(2024, 3, 27, 0, 0, 0, 0, time.UTC)
+	er, err := NewExchangeRate("USD", "INR", "83.40", at)
+	requirer.NoError(err)
+	requirer.NoError(rs.Add(er))
+
+	er, err = rs.Rate(ctx, "USD", "INR", at)
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83.4", er.String())
+
+	er, err = snap.Rate(ctx, "USD", "INR", at)
+	requirer.NoError(err)
+	asserter.Equal("USD/INR 83.25", er.String())
+	asserter.Len(snap.Rates(), 4)
+}
+
+func TestRateStoreWriteCSV(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	rs := newTestRateStore(t, FallbackPrevious)
+	requirer.NoError(rs.Add(ExchangeRate{Base: "USD", Quote: "JPY", Rate: big.NewRat(1, 3), Time: time.Date(2024, 3, 22, 0, 0, 0, 0, time.UTC)}))
+
+	buf := bytes.NewBuffer(nil)
+	requirer.NoError(rs.WriteCSV(buf))
+	asserter.Equal(`base,quote,rate,time
+EUR,USD,1.0811,2024-03-22T00:00:00Z
+USD,INR,83,2024-03-21T00:00:00Z
+USD,INR,83.1,2024-03-22T00:00:00Z
+USD,INR,83.25,2024-03-26T00:00:00Z
+USD,JPY,1/3,2024-03-22T00:00:00Z
+`, buf.String())
+
+	loaded := NewRateStore(FallbackPrevious)
+	requirer.NoError(loaded.LoadCSV(bytes.NewReader(buf.Bytes())))
+	asserter.Equal(rs.Rates(), loaded.Rates())
+}
+
+func TestRateStoreConverter(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	cv := &Converter{
+		Rates:  newTestRateStore(t, FallbackPreviousBusinessDay),
+		Pivots: []string{"USD"},
+	}
+
+	eur, err := New(100, 0, "EUR", "€", "cent", 100)
+	requirer.NoError(err)
+
+	inr, _, err := cv.Convert(context.Background(), eur, "INR", time.Date(2024, 3, 25, 12, 0, 0, 0, time.UTC))
+	requirer.NoError(err)
+	// 100 * 1.0811 * 83.10
+	asserter.Equal("8983.94", inr.StringWithoutSymbols())
+
+	_, _, err = cv.Convert(context.Background(), eur, "INR", time.Date(2024, 3, 27, 12, 0, 0, 0, time.UTC))
+	asserter.ErrorIs(err, ErrRateNotFound)
+}
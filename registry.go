@@ -0,0 +1,186 @@
+package currency
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownCurrency is the error returned when a currency code is not available in the registry
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// Meta is the meta data of a currency, as maintained in the registry.
+type Meta struct {
+	// Code represents the international currency code
+	Code string
+	// Numeric is the ISO 4217 numeric code of the currency
+	Numeric uint16
+	// Symbol is the respective currency symbol
+	Symbol string
+	// FUName is the name of the fractional unit of the currency. e.g. paise
+	FUName string
+	// FUShare represents the number of fractional units that make up 1 main unit. e.g. ₹1 = 100 Paise.
+	FUShare uint
+}
+
+// New returns a new instance of currency, with the meta data of m.
+func (m Meta) New(main int, fractional int) (*Currency, error) {
+	return New(main, fractional, m.Code, m.Symbol, m.FUName, m.FUShare)
+}
+
+// NewFractional returns a new instance of currency with the meta data of m, given the total
+// value of currency in fractional unit.
+func (m Meta) NewFractional(ftotal int) (*Currency, error) {
+	return NewFractional(ftotal, m.Code, m.Symbol, m.FUName, m.FUShare)
+}
+
+// registry holds the meta data of all known currencies, keyed by code
+var registry = struct {
+	sync.RWMutex
+	codes   map[string]Meta
+	numeric map[uint16]string
+}{
+	codes:   make(map[string]Meta),
+	numeric: make(map[uint16]string),
+}
+
+// Register adds a currency to the registry, replacing any existing currency with the same code.
+func Register(m Meta) error {
+	if !validCode(m.Code) {
+		return ErrInvalidCode
+	}
+
+	if m.FUShare == 0 {
+		return ErrInvalidFUS
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if old, ok := registry.codes[m.Code]; ok && old.Numeric != 0 {
+		delete(registry.numeric, old.Numeric)
+	}
+
+	registry.codes[m.Code] = m
+	if m.Numeric != 0 {
+		registry.numeric[m.Numeric] = m.Code
+	}
+
+	return nil
+}
+
+// Lookup returns the meta data of the currency with the given code.
+func Lookup(code string) (Meta, error) {
+	registry.RLock()
+	m, ok := registry.codes[code]
+	registry.RUnlock()
+	if !ok {
+		return Meta{}, ErrUnknownCurrency
+	}
+
+	return m, nil
+}
+
+// LookupNumeric returns the meta data of the currency with the given ISO 4217 numeric code.
+func LookupNumeric(numeric uint16) (Meta, error) {
+	registry.RLock()
+	code, ok := registry.numeric[numeric]
+	registry.RUnlock()
+	if !ok {
+		return Meta{}, ErrUnknownCurrency
+	}
+
+	return Lookup(code)
+}
+
+// Codes returns the codes of all the registered currencies, sorted alphabetically.
+func Codes() []string {
+	registry.RLock()
+	codes := make([]string, 0, len(registry.codes))
+	for code := range registry.codes {
+		codes = append(codes, code)
+	}
+	registry.RUnlock()
+
+	sort.Strings(codes)
+	return codes
+}
+
+// metaFor returns the meta data for code, preferring the meta data already set in c
+// over the registry.
+func (c *Currency) metaFor(code string) (Meta, error) {
+	if c.Code == code && c.FUShare != 0 {
+		return Meta{
+			Code:    c.Code,
+			Symbol:  c.Symbol,
+			FUName:  c.FUName,
+			FUShare: c.FUShare,
+		}, nil
+	}
+
+	return Lookup(code)
+}
+
+// setFractional updates c with the meta data m and the total value in fractional unit, while
+// retaining the symbol preferences of c.
+func (c *Currency) setFractional(m Meta, ftotal int) error {
+	nc, err := m.NewFractional(ftotal)
+	if err != nil {
+		return err
+	}
+
+	nc.PrefixSymbol = c.PrefixSymbol
+	nc.SuffixSymbol = c.SuffixSymbol
+	*c = *nc
+
+	return nil
+}
+
+func init() {
+	for _, m := range iso4217 {
+		_ = Register(m)
+	}
+}
+
+// iso4217 is the list of currencies registered by default
+var iso4217 = []Meta{
+	{Code: "AED", Numeric: 784, Symbol: "د.إ", FUName: "fils", FUShare: 100},
+	{Code: "AUD", Numeric: 36, Symbol: "$", FUName: "cent", FUShare: 100},
+	{Code: "BDT", Numeric: 50, Symbol: "৳", FUName: "poisha", FUShare: 100},
+	{Code: "BHD", Numeric: 48, Symbol: ".د.ب", FUName: "fils", FUShare: 1000},
+	{Code: "BRL", Numeric: 986, Symbol: "R$", FUName: "centavo", FUShare: 100},
+	{Code: "CAD", Numeric: 124, Symbol: "$", FUName: "cent", FUShare: 100},
+	{Code: "CHF", Numeric: 756, Symbol: "Fr.", FUName: "rappen", FUShare: 100},
+	{Code: "CNY", Numeric: 156, Symbol: "¥", FUName: "fen", FUShare: 100},
+	{Code: "CZK", Numeric: 203, Symbol: "Kč", FUName: "haléř", FUShare: 100},
+	{Code: "DKK", Numeric: 208, Symbol: "kr", FUName: "øre", FUShare: 100},
+	{Code: "EUR", Numeric: 978, Symbol: "€", FUName: "cent", FUShare: 100},
+	{Code: "GBP", Numeric: 826, Symbol: "£", FUName: "penny", FUShare: 100},
+	{Code: "HKD", Numeric: 344, Symbol: "$", FUName: "cent", FUShare: 100},
+	{Code: "HUF", Numeric: 348, Symbol: "Ft", FUName: "fillér", FUShare: 100},
+	{Code: "IDR", Numeric: 360, Symbol: "Rp", FUName: "sen", FUShare: 100},
+	{Code: "ILS", Numeric: 376, Symbol: "₪", FUName: "agora", FUShare: 100},
+	{Code: "INR", Numeric: 356, Symbol: "₹", FUName: "paise", FUShare: 100},
+	{Code: "JOD", Numeric: 400, Symbol: "د.ا", FUName: "fils", FUShare: 1000},
+	{Code: "JPY", Numeric: 392, Symbol: "¥", FUName: "", FUShare: 1},
+	{Code: "KRW", Numeric: 410, Symbol: "₩", FUName: "", FUShare: 1},
+	{Code: "KWD", Numeric: 414, Symbol: "د.ك", FUName: "fils", FUShare: 1000},
+	{Code: "LKR", Numeric: 144, Symbol: "Rs", FUName: "cent", FUShare: 100},
+	{Code: "MXN", Numeric: 484, Symbol: "$", FUName: "centavo", FUShare: 100},
+	{Code: "MYR", Numeric: 458, Symbol: "RM", FUName: "sen", FUShare: 100},
+	{Code: "NOK", Numeric: 578, Symbol: "kr", FUName: "øre", FUShare: 100},
+	{Code: "NPR", Numeric: 524, Symbol: "रू", FUName: "paisa", FUShare: 100},
+	{Code: "NZD", Numeric: 554, Symbol: "$", FUName: "cent", FUShare: 100},
+	{Code: "OMR", Numeric: 512, Symbol: "ر.ع.", FUName: "baisa", FUShare: 1000},
+	{Code: "PHP", Numeric: 608, Symbol: "₱", FUName: "sentimo", FUShare: 100},
+	{Code: "PKR", Numeric: 586, Symbol: "₨", FUName: "paisa", FUShare: 100},
+	{Code: "PLN", Numeric: 985, Symbol: "zł", FUName: "grosz", FUShare: 100},
+	{Code: "RUB", Numeric: 643, Symbol: "₽", FUName: "kopeck", FUShare: 100},
+	{Code: "SAR", Numeric: 682, Symbol: "﷼", FUName: "halala", FUShare: 100},
+	{Code: "SEK", Numeric: 752, Symbol: "kr", FUName: "öre", FUShare: 100},
+	{Code: "SGD", Numeric: 702, Symbol: "$", FUName: "cent", FUShare: 100},
+	{Code: "THB", Numeric: 764, Symbol: "฿", FUName: "satang", FUShare: 100},
+	{Code: "TRY", Numeric: 949, Symbol: "₺", FUName: "kuruş", FUShare: 100},
+	{Code: "USD", Numeric: 840, Symbol: "$", FUName: "cent", FUShare: 100},
+	{Code: "ZAR", Numeric: 710, Symbol: "R", FUName: "cent", FUShare: 100},
+}
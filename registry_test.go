@@ -0,0 +1,55 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	m, err := Lookup("INR")
+	requirer.NoError(err)
+	asserter.Equal(Meta{Code: "INR", Numeric: 356, Symbol: "₹", FUName: "paise", FUShare: 100}, m)
+
+	m, err = LookupNumeric(392)
+	requirer.NoError(err)
+	asserter.Equal("JPY", m.Code)
+	asserter.Equal(uint(1), m.FUShare)
+
+	_, err = Lookup("XYZ")
+	asserter.ErrorIs(err, ErrUnknownCurrency)
+
+	_, err = LookupNumeric(1)
+	asserter.ErrorIs(err, ErrUnknownCurrency)
+
+	asserter.Contains(Codes(), "USD")
+}
+
+func TestRegister(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	asserter.ErrorIs(Register(Meta{Code: "xx", FUShare: 100}), ErrInvalidCode)
+	asserter.ErrorIs(Register(Meta{Code: "XTS", FUShare: 0}), ErrInvalidFUS)
+
+	requirer.NoError(Register(Meta{Code: "XTS", Numeric: 963, Symbol: "T", FUName: "tick", FUShare: 1000}))
+	requirer.NoError(Register(Meta{Code: "XTS", Numeric: 964, Symbol: "T", FUName: "tick", FUShare: 1000}))
+
+	_, err := LookupNumeric(963)
+	asserter.ErrorIs(err, ErrUnknownCurrency)
+
+	m, err := LookupNumeric(964)
+	requirer.NoError(err)
+
+	cur, err := m.New(1, 5)
+	requirer.NoError(err)
+	asserter.Equal("1.005", cur.StringWithoutSymbols())
+
+	cur, err = m.NewFractional(-2500)
+	requirer.NoError(err)
+	asserter.Equal("-2.500", cur.StringWithoutSymbols())
+}
@@ -0,0 +1,145 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrInvalidRoundingMode is the error returned when an unknown rounding mode is provided
+var ErrInvalidRoundingMode = errors.New("invalid rounding mode provided")
+
+// RoundingMode is the rule used to round a value to the fractional unit of a currency.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest fractional unit, ties away from zero. e.g. 2.5 => 3, -2.5 => -3
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest fractional unit, ties to the even neighbour (banker's rounding). e.g. 2.5 => 2, 3.5 => 4
+	RoundHalfEven
+	// RoundHalfDown rounds to the nearest fractional unit, ties towards zero. e.g. 2.5 => 2, -2.5 => -2
+	RoundHalfDown
+	// RoundUp rounds away from zero. e.g. 2.1 => 3, -2.1 => -3
+	RoundUp
+	// RoundDown rounds towards zero, i.e. truncates. e.g. 2.9 => 2, -2.9 => -2
+	RoundDown
+	// RoundCeiling rounds towards positive infinity. e.g. 2.1 => 3, -2.9 => -2
+	RoundCeiling
+	// RoundFloor rounds towards negative infinity. e.g. 2.9 => 2, -2.1 => -3
+	RoundFloor
+)
+
+func (rm RoundingMode) String() string {
+	switch rm {
+	case RoundHalfUp:
+		return "HalfUp"
+	case RoundHalfEven:
+		return "HalfEven"
+	case RoundHalfDown:
+		return "HalfDown"
+	case RoundUp:
+		return "Up"
+	case RoundDown:
+		return "Down"
+	case RoundCeiling:
+		return "Ceiling"
+	case RoundFloor:
+		return "Floor"
+	}
+
+	return fmt.Sprintf("RoundingMode(%d)", int(rm))
+}
+
+// roundingModes are all the valid rounding modes
+var roundingModes = []RoundingMode{RoundHalfUp, RoundHalfEven, RoundHalfDown, RoundUp, RoundDown, RoundCeiling, RoundFloor}
+
+// MarshalText implements encoding.TextMarshaler, encoding the rounding mode by its name, e.g. HalfEven.
+func (rm RoundingMode) MarshalText() ([]byte, error) {
+	for _, m := range roundingModes {
+		if m == rm {
+			return []byte(rm.String()), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrInvalidRoundingMode, int(rm))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding the rounding mode from its name,
+// e.g. HalfEven. The name is case insensitive.
+func (rm *RoundingMode) UnmarshalText(text []byte) error {
+	for _, m := range roundingModes {
+		if strings.EqualFold(m.String(), string(text)) {
+			*rm = m
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %q", ErrInvalidRoundingMode, string(text))
+}
+
+// roundRat rounds r to an integer using the rounding mode.
+func roundRat(r *big.Rat, mode RoundingMode) (*big.Int, error) {
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+	if rem.Sign() == 0 {
+		switch mode {
+		case RoundHalfUp, RoundHalfEven, RoundHalfDown, RoundUp, RoundDown, RoundCeiling, RoundFloor:
+			return q, nil
+		}
+
+		return nil, ErrInvalidRoundingMode
+	}
+
+	sign := big.NewInt(int64(r.Sign()))
+	// half is the comparison of the remainder with half of the denominator
+	half := new(big.Int).Abs(rem)
+	half = half.Lsh(half, 1)
+	cmp := half.Cmp(r.Denom())
+
+	away := false
+	switch mode {
+	case RoundHalfUp:
+		away = cmp >= 0
+	case RoundHalfEven:
+		away = cmp > 0 || (cmp == 0 && q.Bit(0) == 1)
+	case RoundHalfDown:
+		away = cmp > 0
+	case RoundUp:
+		away = true
+	case RoundDown:
+		away = false
+	case RoundCeiling:
+		away = r.Sign() > 0
+	case RoundFloor:
+		away = r.Sign() < 0
+	default:
+		return nil, ErrInvalidRoundingMode
+	}
+
+	if away {
+		q = q.Add(q, sign)
+	}
+
+	return q, nil
+}
+
+// roundFractional rounds r, a value in fractional units, to an int using the rounding mode.
+func roundFractional(r *big.Rat, mode RoundingMode) (int, error) {
+	n, err := roundRat(r, mode)
+	if err != nil {
+		return 0, err
+	}
+
+	return ratInt(new(big.Rat).SetInt(n))
+}
+
+// roundDecimals rounds r to the given number of decimal places using the rounding mode.
+func roundDecimals(r *big.Rat, decimals int, mode RoundingMode) (*big.Rat, error) {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	n, err := roundRat(new(big.Rat).Mul(r, new(big.Rat).SetInt(scale)), mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Rat).SetFrac(n, scale), nil
+}
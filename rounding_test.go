@@ -0,0 +1,65 @@
+package currency
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoundRat(t *testing.T) {
+	asserter := assert.New(t)
+
+	inputs := []string{"2.5", "-2.5", "3.5", "2.1", "-2.1", "2.9", "-2.9", "2", "-2", "0.5", "-0.5"}
+	list := []struct {
+		Mode     RoundingMode
+		Expected []int64
+	}{
+		{Mode: RoundHalfUp, Expected: []int64{3, -3, 4, 2, -2, 3, -3, 2, -2, 1, -1}},
+		{Mode: RoundHalfEven, Expected: []int64{2, -2, 4, 2, -2, 3, -3, 2, -2, 0, 0}},
+		{Mode: RoundHalfDown, Expected: []int64{2, -2, 3, 2, -2, 3, -3, 2, -2, 0, 0}},
+		{Mode: RoundUp, Expected: []int64{3, -3, 4, 3, -3, 3, -3, 2, -2, 1, -1}},
+		{Mode: RoundDown, Expected: []int64{2, -2, 3, 2, -2, 2, -2, 2, -2, 0, 0}},
+		{Mode: RoundCeiling, Expected: []int64{3, -2, 4, 3, -2, 3, -2, 2, -2, 1, 0}},
+		{Mode: RoundFloor, Expected: []int64{2, -3, 3, 2, -3, 2, -3, 2, -2, 0, -1}},
+	}
+
+	for _, l := range list {
+		for idx, inp := range inputs {
+			r, _ := new(big.Rat).SetString(inp)
+			got, err := roundRat(r, l.Mode)
+			if asserter.NoError(err) {
+				asserter.Equal(l.Expected[idx], got.Int64(), l.Mode.String()+" "+inp)
+			}
+		}
+	}
+
+	_, err := roundRat(big.NewRat(5, 2), RoundingMode(99))
+	asserter.ErrorIs(err, ErrInvalidRoundingMode)
+
+	_, err = roundRat(big.NewRat(2, 1), RoundingMode(99))
+	asserter.ErrorIs(err, ErrInvalidRoundingMode)
+	asserter.Equal("RoundingMode(99)", RoundingMode(99).String())
+}
+
+func TestRoundingModeText(t *testing.T) {
+	asserter := assert.New(t)
+
+	for _, mode := range roundingModes {
+		text, err := mode.MarshalText()
+		asserter.NoError(err)
+
+		var got RoundingMode
+		asserter.NoError(got.UnmarshalText(text))
+		asserter.Equal(mode, got)
+	}
+
+	var mode RoundingMode
+	asserter.NoError(mode.UnmarshalText([]byte("halfeven")))
+	asserter.Equal(RoundHalfEven, mode)
+
+	asserter.ErrorIs(mode.UnmarshalText([]byte("nearest")), ErrInvalidRoundingMode)
+
+	_, err := RoundingMode(99).MarshalText()
+	asserter.ErrorIs(err, ErrInvalidRoundingMode)
+}
@@ -0,0 +1,253 @@
+package currency
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// ErrNullValue is the error returned when scanning a NULL column into a Currency. Use NullCurrency for nullable columns.
+var ErrNullValue = errors.New("cannot scan NULL into currency")
+
+// SQLFormat is the representation of a currency amount in a database column.
+type SQLFormat int
+
+const (
+	// SQLDecimal stores the amount as a decimal string, for numeric/decimal columns. e.g. "12.50"
+	SQLDecimal SQLFormat = iota
+	// SQLMinorUnits stores the amount as an integer of fractional units, for integer columns. e.g. 1250
+	SQLMinorUnits
+)
+
+// DefaultSQLFormat is the format used by Currency's Value & Scan methods.
+var DefaultSQLFormat = SQLDecimal
+
+// Value implements driver.Valuer, the amount is stored in DefaultSQLFormat. The zero value is stored as NULL.
+func (c Currency) Value() (driver.Value, error) {
+	return c.sqlValue(DefaultSQLFormat)
+}
+
+// Scan implements sql.Scanner, the column is read in DefaultSQLFormat. c must already have
+// the meta data of the currency set, since the column only holds the amount.
+func (c *Currency) Scan(src interface{}) error {
+	return c.sqlScan(src, DefaultSQLFormat)
+}
+
+// AsSQL returns an adapter to store & scan c using the given format, regardless of DefaultSQLFormat.
+func (c *Currency) AsSQL(f SQLFormat) *SQLAmount {
+	return &SQLAmount{Currency: c, Format: f}
+}
+
+// SQLAmount is a driver.Valuer & sql.Scanner for a currency amount column of the given format.
+type SQLAmount struct {
+	Currency *Currency
+	Format   SQLFormat
+}
+
+// Value implements driver.Valuer
+func (sa *SQLAmount) Value() (driver.Value, error) {
+	return sa.Currency.sqlValue(sa.Format)
+}
+
+// Scan implements sql.Scanner
+func (sa *SQLAmount) Scan(src interface{}) error {
+	return sa.Currency.sqlScan(src, sa.Format)
+}
+
+// NullCurrency represents a currency amount column which may be NULL. Currency must already have
+// the meta data of the currency set before scanning.
+type NullCurrency struct {
+	Currency Currency
+	// Valid is true if the column is not NULL
+	Valid bool
+	// Format is the format of the amount column
+	Format SQLFormat
+}
+
+// Value implements driver.Valuer
+func (nc NullCurrency) Value() (driver.Value, error) {
+	if !nc.Valid {
+		return nil, nil
+	}
+
+	return nc.Currency.sqlValue(nc.Format)
+}
+
+// Scan implements sql.Scanner
+func (nc *NullCurrency) Scan(src interface{}) error {
+	if src == nil {
+		nc.Valid = false
+		return nil
+	}
+
+	err := nc.Currency.sqlScan(src, nc.Format)
+	if err != nil {
+		return err
+	}
+
+	nc.Valid = true
+	return nil
+}
+
+// ScanColumns returns a pair of sql.Scanner for an amount column of the given format, and a
+// currency code column. Once both are scanned, c is updated with the amount & the meta data of
+// the currency from the registry. e.g.
+//
+//	amount, code := c.ScanColumns(currency.SQLMinorUnits)
+//	err := row.Scan(&id, amount, code)
+func (c *Currency) ScanColumns(f SQLFormat) (amount sql.Scanner, code sql.Scanner) {
+	cs := &columnScanner{target: c, format: f}
+	return scanFunc(cs.scanAmount), scanFunc(cs.scanCode)
+}
+
+// scanFunc is a function which implements sql.Scanner
+type scanFunc func(src interface{}) error
+
+func (sf scanFunc) Scan(src interface{}) error {
+	return sf(src)
+}
+
+// columnScanner collects an amount & a currency code column, and updates target once both are
+// scanned. The columns are cleared after every update, so that the scanners can be reused for
+// every row.
+type columnScanner struct {
+	target *Currency
+	format SQLFormat
+
+	amount     interface{}
+	code       string
+	haveAmount bool
+	haveCode   bool
+}
+
+func (cs *columnScanner) scanAmount(src interface{}) error {
+	if b, ok := src.([]byte); ok {
+		// the driver may reuse the byte slice after Scan returns
+		src = string(b)
+	}
+
+	cs.amount = src
+	cs.haveAmount = true
+	return cs.resolve()
+}
+
+func (cs *columnScanner) scanCode(src interface{}) error {
+	switch v := src.(type) {
+	case string:
+		cs.code = v
+	case []byte:
+		cs.code = string(v)
+	case nil:
+		return ErrNullValue
+	default:
+		return fmt.Errorf("%w: unsupported currency code type %T", ErrInvalidCode, src)
+	}
+
+	cs.haveCode = true
+	return cs.resolve()
+}
+
+func (cs *columnScanner) resolve() error {
+	if !cs.haveAmount || !cs.haveCode {
+		return nil
+	}
+
+	amount, code := cs.amount, cs.code
+	cs.amount, cs.code = nil, ""
+	cs.haveAmount, cs.haveCode = false, false
+
+	m, err := Lookup(code)
+	if err != nil {
+		return fmt.Errorf("%w: %q", err, code)
+	}
+
+	c := Currency{
+		Code:         m.Code,
+		Symbol:       m.Symbol,
+		FUName:       m.FUName,
+		FUShare:      m.FUShare,
+		PrefixSymbol: cs.target.PrefixSymbol,
+		SuffixSymbol: cs.target.SuffixSymbol,
+	}
+
+	err = c.sqlScan(amount, cs.format)
+	if err != nil {
+		return err
+	}
+
+	*cs.target = c
+	return nil
+}
+
+// sqlValue returns the driver value of c in the given format
+func (c *Currency) sqlValue(f SQLFormat) (driver.Value, error) {
+	if c.isBlank() {
+		return nil, nil
+	}
+
+	if c.FUShare == 0 {
+		return nil, ErrInvalidFUS
+	}
+
+	switch f {
+	case SQLDecimal:
+		return c.decimalString(), nil
+	case SQLMinorUnits:
+		return int64(c.FractionalTotal()), nil
+	}
+
+	return nil, fmt.Errorf("unsupported SQL format %d", f)
+}
+
+// sqlScan updates the amount of c from the driver value src of the given format
+func (c *Currency) sqlScan(src interface{}, f SQLFormat) error {
+	if c.FUShare == 0 {
+		return ErrInvalidFUS
+	}
+
+	m := Meta{Code: c.Code, Symbol: c.Symbol, FUName: c.FUName, FUShare: c.FUShare}
+
+	switch v := src.(type) {
+	case nil:
+		return ErrNullValue
+	case int64:
+		if f == SQLMinorUnits {
+			return c.setFractional(m, int(v))
+		}
+
+		return c.setDecimal(m, strconv.FormatInt(v, 10))
+	case float64:
+		if f == SQLMinorUnits {
+			if math.Trunc(v) != v || v < math.MinInt64 || v >= math.MaxInt64 {
+				return fmt.Errorf("%w: %v", ErrInvalidCurrency, v)
+			}
+
+			return c.setFractional(m, int(v))
+		}
+
+		return c.setDecimal(m, strconv.FormatFloat(v, 'f', -1, 64))
+	case []byte:
+		return c.scanString(m, string(v), f)
+	case string:
+		return c.scanString(m, v, f)
+	}
+
+	return fmt.Errorf("%w: unsupported column type %T", ErrInvalidCurrency, src)
+}
+
+// scanString updates the amount of c from a textual column value of the given format
+func (c *Currency) scanString(m Meta, str string, f SQLFormat) error {
+	if f == SQLMinorUnits {
+		ftotal, err := strconv.Atoi(str)
+		if err != nil {
+			return fmt.Errorf("%w: %q", ErrInvalidCurrency, str)
+		}
+
+		return c.setFractional(m, ftotal)
+	}
+
+	return c.setDecimal(m, str)
+}
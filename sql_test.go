@@ -0,0 +1,339 @@
+package currency
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDB is an in-memory store used by fakeDriver, it records the arguments of every Exec
+// and returns the configured rows for every Query
+type fakeDB struct {
+	sync.Mutex
+	execArgs [][]driver.Value
+	columns  []string
+	rows     [][]driver.Value
+}
+
+type fakeDriver struct {
+	db *fakeDB
+}
+
+func (fd *fakeDriver) Open(string) (driver.Conn, error) {
+	return &fakeConn{db: fd.db}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (fc *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return &fakeStmt{db: fc.db}, nil
+}
+
+func (fc *fakeConn) Close() error {
+	return nil
+}
+
+func (fc *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+type fakeStmt struct {
+	db *fakeDB
+}
+
+func (fs *fakeStmt) Close() error {
+	return nil
+}
+
+func (fs *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (fs *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	fs.db.Lock()
+	fs.db.execArgs = append(fs.db.execArgs, args)
+	fs.db.Unlock()
+	return driver.RowsAffected(1), nil
+}
+
+func (fs *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{columns: fs.db.columns, rows: fs.db.rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	idx     int
+}
+
+func (fr *fakeRows) Columns() []string {
+	return fr.columns
+}
+
+func (fr *fakeRows) Close() error {
+	return nil
+}
+
+func (fr *fakeRows) Next(dest []driver.Value) error {
+	if fr.idx >= len(fr.rows) {
+		return io.EOF
+	}
+
+	copy(dest, fr.rows[fr.idx])
+	fr.idx++
+	return nil
+}
+
+func openFakeDB(t *testing.T, fdb *fakeDB) *sql.DB {
+	db := sql.OpenDB(fakeConnector{db: fdb})
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	return db
+}
+
+type fakeConnector struct {
+	db *fakeDB
+}
+
+func (fc fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{db: fc.db}, nil
+}
+
+func (fc fakeConnector) Driver() driver.Driver {
+	return &fakeDriver{db: fc.db}
+}
+
+func TestSQLValue(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	fdb := &fakeDB{}
+	db := openFakeDB(t, fdb)
+
+	usd, err := New(12, 50, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	neg, err := NewFractional(-5, "KWD", "د.ك", "fils", 1000)
+	requirer.NoError(err)
+
+	_, err = db.Exec(
+		"INSERT INTO payments VALUES (?, ?, ?, ?, ?, ?)",
+		usd,
+		*neg,
+		usd.AsSQL(SQLMinorUnits),
+		NullCurrency{Currency: *usd, Valid: true, Format: SQLMinorUnits},
+		NullCurrency{},
+		Currency{},
+	)
+	requirer.NoError(err)
+
+	requirer.Len(fdb.execArgs, 1)
+	asserter.Equal(
+		[]driver.Value{"12.50", "-0.005", int64(1250), int64(1250), nil, nil},
+		fdb.execArgs[0],
+	)
+
+	DefaultSQLFormat = SQLMinorUnits
+	defer func() {
+		DefaultSQLFormat = SQLDecimal
+	}()
+
+	_, err = db.Exec("INSERT INTO payments VALUES (?)", usd)
+	requirer.NoError(err)
+	asserter.Equal([]driver.Value{int64(1250)}, fdb.execArgs[1])
+
+	_, err = db.Exec("INSERT INTO payments VALUES (?)", Currency{Code: "USD", Main: 1})
+	asserter.ErrorIs(err, ErrInvalidFUS)
+}
+
+func TestSQLScan(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	fdb := &fakeDB{
+		columns: []string{"numeric", "bigint", "nullable", "float"},
+		rows: [][]driver.Value{
+			{[]byte("12.5000"), int64(-1250), nil, float64(0.1)},
+			{"7", int64(5), []byte("0.05"), float64(3)},
+		},
+	}
+	db := openFakeDB(t, fdb)
+
+	rows, err := db.Query("SELECT * FROM payments")
+	requirer.NoError(err)
+	defer rows.Close()
+
+	expected := []struct {
+		Numeric  string
+		Bigint   string
+		Nullable string
+		Valid    bool
+		Float    string
+	}{
+		{Numeric: "12.50", Bigint: "-12.50", Valid: false, Float: "0.10"},
+		{Numeric: "7.00", Bigint: "0.05", Nullable: "0.05", Valid: true, Float: "3.00"},
+	}
+
+	idx := 0
+	for rows.Next() {
+		numeric, _ := New(0, 0, "USD", "$", "cent", 100)
+		bigint, _ := New(0, 0, "USD", "$", "cent", 100)
+		float, _ := New(0, 0, "USD", "$", "cent", 100)
+		nullable := NullCurrency{Currency: *numeric}
+
+		requirer.NoError(rows.Scan(numeric, bigint.AsSQL(SQLMinorUnits), &nullable, float))
+
+		asserter.Equal(expected[idx].Numeric, numeric.String())
+		asserter.Equal(expected[idx].Bigint, bigint.String())
+		asserter.Equal(expected[idx].Valid, nullable.Valid)
+		if nullable.Valid {
+			asserter.Equal(expected[idx].Nullable, nullable.Currency.String())
+		}
+		asserter.Equal(expected[idx].Float, float.String())
+		idx++
+	}
+	requirer.NoError(rows.Err())
+	asserter.Equal(2, idx)
+
+	// drivers may return integer columns as float64
+	minor := Currency{Code: "USD", FUShare: 100}
+	requirer.NoError(minor.sqlScan(float64(1250), SQLMinorUnits))
+	asserter.Equal("12.50", minor.String())
+	requirer.NoError(minor.sqlScan(float64(-5), SQLMinorUnits))
+	asserter.Equal("-0.05", minor.String())
+}
+
+func TestSQLScanErrors(t *testing.T) {
+	asserter := assert.New(t)
+
+	list := []struct {
+		Name   string
+		Target Currency
+		Src    interface{}
+		Format SQLFormat
+		Err    error
+	}{
+		{Name: "no meta data", Target: Currency{}, Src: "1.00", Err: ErrInvalidFUS},
+		{Name: "null", Target: Currency{Code: "USD", FUShare: 100}, Src: nil, Err: ErrNullValue},
+		{Name: "precision", Target: Currency{Code: "USD", FUShare: 100}, Src: "1.005", Err: ErrPrecisionLoss},
+		{Name: "float precision", Target: Currency{Code: "USD", FUShare: 100}, Src: float64(1.005), Err: ErrPrecisionLoss},
+		{Name: "invalid minor units", Target: Currency{Code: "USD", FUShare: 100}, Src: "1.5", Format: SQLMinorUnits, Err: ErrInvalidCurrency},
+		{Name: "float minor units", Target: Currency{Code: "USD", FUShare: 100}, Src: float64(12.5), Format: SQLMinorUnits, Err: ErrInvalidCurrency},
+		{Name: "float minor units overflow", Target: Currency{Code: "USD", FUShare: 100}, Src: float64(1e19), Format: SQLMinorUnits, Err: ErrInvalidCurrency},
+		{Name: "unsupported type", Target: Currency{Code: "USD", FUShare: 100}, Src: true, Err: ErrInvalidCurrency},
+	}
+
+	for _, l := range list {
+		asserter.ErrorIs(l.Target.sqlScan(l.Src, l.Format), l.Err, l.Name)
+	}
+}
+
+func TestScanColumns(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	fdb := &fakeDB{
+		columns: []string{"amount", "code"},
+		rows: [][]driver.Value{
+			{int64(1250), []byte("USD")},
+			{int64(-2500), "KWD"},
+			{int64(100), "XYZ"},
+		},
+	}
+	db := openFakeDB(t, fdb)
+
+	rows, err := db.Query("SELECT amount, code FROM payments")
+	requirer.NoError(err)
+	defer rows.Close()
+
+	expected := []struct {
+		Str string
+		Err error
+	}{
+		{Str: "$12.50"},
+		{Str: "-د.ك2.500"},
+		{Err: ErrUnknownCurrency},
+	}
+
+	idx := 0
+	for rows.Next() {
+		cur := Currency{PrefixSymbol: true}
+		amount, code := cur.ScanColumns(SQLMinorUnits)
+		err := rows.Scan(amount, code)
+		if expected[idx].Err != nil {
+			asserter.ErrorIs(err, expected[idx].Err)
+		} else {
+			requirer.NoError(err)
+			asserter.Equal(expected[idx].Str, cur.String())
+		}
+		idx++
+	}
+	asserter.Equal(3, idx)
+
+	cur := Currency{}
+	amount, code := cur.ScanColumns(SQLDecimal)
+	requirer.NoError(code.Scan("INR"))
+	requirer.NoError(amount.Scan([]byte("10.5")))
+	asserter.Equal("10.50", cur.String())
+	asserter.Equal("₹", cur.Symbol)
+
+	_, code = cur.ScanColumns(SQLDecimal)
+	asserter.ErrorIs(code.Scan(nil), ErrNullValue)
+}
+
+func TestScanColumnsReuse(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	fdb := &fakeDB{
+		columns: []string{"amount", "code"},
+		rows: [][]driver.Value{
+			{"1250", "JPY"},
+			{"12.50", "USD"},
+			{[]byte("2.125"), []byte("KWD")},
+			{"0.05", "INR"},
+		},
+	}
+	db := openFakeDB(t, fdb)
+
+	rows, err := db.Query("SELECT amount, code FROM payments")
+	requirer.NoError(err)
+	defer rows.Close()
+
+	// the same scanners are used for every row
+	cur := Currency{}
+	amount, code := cur.ScanColumns(SQLDecimal)
+
+	codes := []string{}
+	ftotals := []int{}
+	for rows.Next() {
+		requirer.NoError(rows.Scan(amount, code))
+		codes = append(codes, cur.Code)
+		ftotals = append(ftotals, cur.FractionalTotal())
+	}
+	requirer.NoError(rows.Err())
+	asserter.Equal([]string{"JPY", "USD", "KWD", "INR"}, codes)
+	asserter.Equal([]int{1250, 1250, 2125, 5}, ftotals)
+
+	// the code column is scanned first
+	requirer.NoError(code.Scan("JPY"))
+	requirer.NoError(amount.Scan("7"))
+	asserter.Equal("JPY", cur.Code)
+	asserter.Equal(7, cur.FractionalTotal())
+	requirer.NoError(code.Scan("USD"))
+	asserter.Equal("JPY", cur.Code)
+	requirer.NoError(amount.Scan("0.25"))
+	asserter.Equal("USD", cur.Code)
+	asserter.Equal(25, cur.FractionalTotal())
+}
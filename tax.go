@@ -0,0 +1,145 @@
+package currency
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// TaxComponent is a component of the tax levied on an amount, e.g. VAT or a state sales tax.
+type TaxComponent struct {
+	// Name of the component, e.g. VAT
+	Name string
+	// Rate of the component, e.g. 20%
+	Rate Rate
+	// Compound if true, levies the component on the net amount plus all the preceding
+	// components (i.e. tax on tax), otherwise only on the net amount
+	Compound bool
+}
+
+// TaxAmount is the amount of a tax component.
+type TaxAmount struct {
+	TaxComponent
+	Amount Currency
+}
+
+// TaxBreakdown is the net, tax & gross amounts, along with the amount of every tax component.
+// Net + Tax is always equal to Gross, and the amounts of the components add up to Tax.
+type TaxBreakdown struct {
+	Net        Currency
+	Tax        Currency
+	Gross      Currency
+	Components []TaxAmount
+}
+
+// TaxExclusive computes the tax levied on c, which is the net amount, i.e. tax-exclusive
+// pricing. Every component is computed exactly & rounded to the fractional unit using the
+// rounding mode, and the gross amount is the net amount plus the rounded components.
+func (c *Currency) TaxExclusive(mode RoundingMode, components ...TaxComponent) (*TaxBreakdown, error) {
+	if c.FUShare == 0 {
+		return nil, ErrInvalidFUS
+	}
+
+	factors, _ := taxFactors(components)
+
+	tb := &TaxBreakdown{Net: *c, Tax: *c, Components: make([]TaxAmount, 0, len(components))}
+	tb.Tax.UpdateWithFractional(0)
+
+	for i, tc := range components {
+		amount, err := c.scaled(factors[i], mode)
+		if err != nil {
+			return nil, err
+		}
+
+		tb.Tax.UpdateWithFractional(tb.Tax.FractionalTotal() + amount.FractionalTotal())
+		tb.Components = append(tb.Components, TaxAmount{TaxComponent: tc, Amount: *amount})
+	}
+
+	tb.Gross = *c
+	err := tb.Gross.UpdateWithFractionalE(c.FractionalTotal() + tb.Tax.FractionalTotal())
+	if err != nil {
+		return nil, err
+	}
+
+	return tb, nil
+}
+
+// TaxInclusive extracts the tax included in c, which is the gross amount, i.e. tax-inclusive
+// pricing. The net amount is gross / (1 + effective rate) rounded to the fractional unit using
+// the rounding mode, the tax is the rest of the gross amount, and it's allocated to the
+// components in proportion to their exact amounts.
+func (c *Currency) TaxInclusive(mode RoundingMode, components ...TaxComponent) (*TaxBreakdown, error) {
+	if c.FUShare == 0 {
+		return nil, ErrInvalidFUS
+	}
+
+	factors, multiplier := taxFactors(components)
+	if multiplier.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: effective tax rate must be more than -100%%", ErrInvalidFactor)
+	}
+
+	net, err := c.scaled(new(big.Rat).Inv(multiplier), mode)
+	if err != nil {
+		return nil, err
+	}
+
+	tb := &TaxBreakdown{Net: *net, Tax: *c, Gross: *c, Components: make([]TaxAmount, 0, len(components))}
+	tb.Tax.UpdateWithFractional(c.FractionalTotal() - net.FractionalTotal())
+
+	amounts := make([]Currency, len(components))
+	if tb.Tax.FractionalTotal() != 0 {
+		amounts, err = tb.Tax.allocate(ratWeights(factors))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for i := range amounts {
+			amounts[i] = tb.Tax
+		}
+	}
+
+	for i, tc := range components {
+		tb.Components = append(tb.Components, TaxAmount{TaxComponent: tc, Amount: amounts[i]})
+	}
+
+	return tb, nil
+}
+
+// taxFactors returns the exact fraction of the net amount levied by every component, and the
+// multiplier from the net to the gross amount, i.e. 1 + the sum of the factors
+func taxFactors(components []TaxComponent) ([]*big.Rat, *big.Rat) {
+	factors := make([]*big.Rat, 0, len(components))
+	multiplier := big.NewRat(1, 1)
+	for _, tc := range components {
+		base := big.NewRat(1, 1)
+		if tc.Compound {
+			base.Set(multiplier)
+		}
+
+		f := base.Mul(base, tc.Rate.Rat())
+		factors = append(factors, f)
+		multiplier.Add(multiplier, f)
+	}
+
+	return factors, multiplier
+}
+
+// ratWeights returns integer weights in the same proportion as the rationals, by scaling them
+// to their least common denominator. Negative rationals are treated as 0.
+func ratWeights(rats []*big.Rat) []*big.Int {
+	lcd := big.NewInt(1)
+	for _, r := range rats {
+		gcd := new(big.Int).GCD(nil, nil, lcd, r.Denom())
+		lcd.Mul(lcd, new(big.Int).Quo(r.Denom(), gcd))
+	}
+
+	weights := make([]*big.Int, 0, len(rats))
+	for _, r := range rats {
+		w := new(big.Int).Mul(r.Num(), new(big.Int).Quo(lcd, r.Denom()))
+		if w.Sign() < 0 {
+			w.SetInt64(0)
+		}
+		weights = append(weights, w)
+	}
+
+	return weights
+}
@@ -0,0 +1,199 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func taxAmounts(tb *TaxBreakdown) []string {
+	amounts := []string{}
+	for _, ta := range tb.Components {
+		amounts = append(amounts, ta.Name+" "+ta.Amount.StringWithoutSymbols())
+	}
+
+	return amounts
+}
+
+func TestTaxExclusive(t *testing.T) {
+	gst := TaxComponent{Name: "GST", Rate: MustParseRate("5%")}
+	pst := TaxComponent{Name: "PST", Rate: MustParseRate("7%")}
+	qst := TaxComponent{Name: "QST", Rate: MustParseRate("9.5%"), Compound: true}
+
+	tests := []struct {
+		name       string
+		net        int
+		components []TaxComponent
+		tax        string
+		gross      string
+		amounts    []string
+	}{
+		{
+			name:       "single",
+			net:        10000,
+			components: []TaxComponent{{Name: "VAT", Rate: MustParseRate("20%")}},
+			tax:        "20.00",
+			gross:      "120.00",
+			amounts:    []string{"VAT 20.00"},
+		},
+		{
+			name:       "stacked",
+			net:        9999,
+			components: []TaxComponent{gst, pst},
+			tax:        "12.00",
+			gross:      "111.99",
+			amounts:    []string{"GST 5.00", "PST 7.00"},
+		},
+		{
+			name:       "compound",
+			net:        10000,
+			components: []TaxComponent{gst, qst},
+			// QST = (100 + 5) × 9.5% = 9.975
+			tax:     "14.98",
+			gross:   "114.98",
+			amounts: []string{"GST 5.00", "QST 9.98"},
+		},
+		{
+			name:       "refund",
+			net:        -10000,
+			components: []TaxComponent{gst, qst},
+			tax:        "-14.98",
+			gross:      "-114.98",
+			amounts:    []string{"GST -5.00", "QST -9.98"},
+		},
+		{
+			name:    "no tax",
+			net:     10000,
+			tax:     "0.00",
+			gross:   "100.00",
+			amounts: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			net, err := NewFractional(tt.net, "CAD", "$", "cent", 100)
+			require.NoError(t, err)
+
+			tb, err := net.TaxExclusive(RoundHalfUp, tt.components...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.net, tb.Net.FractionalTotal())
+			assert.Equal(t, tt.tax, tb.Tax.StringWithoutSymbols())
+			assert.Equal(t, tt.gross, tb.Gross.StringWithoutSymbols())
+			assert.Equal(t, tt.amounts, taxAmounts(tb))
+			assert.Equal(t, "CAD", tb.Gross.Code)
+		})
+	}
+}
+
+func TestTaxInclusive(t *testing.T) {
+	cgst := TaxComponent{Name: "CGST", Rate: MustParseRate("9%")}
+	sgst := TaxComponent{Name: "SGST", Rate: MustParseRate("9%")}
+
+	tests := []struct {
+		name       string
+		gross      int
+		components []TaxComponent
+		net        string
+		tax        string
+		amounts    []string
+	}{
+		{
+			name:       "exact",
+			gross:      11800,
+			components: []TaxComponent{{Name: "GST", Rate: MustParseRate("18%")}},
+			net:        "100.00",
+			tax:        "18.00",
+			amounts:    []string{"GST 18.00"},
+		},
+		{
+			name:       "rounded",
+			gross:      10000,
+			components: []TaxComponent{{Name: "VAT", Rate: MustParseRate("20%")}},
+			net:        "83.33",
+			tax:        "16.67",
+			amounts:    []string{"VAT 16.67"},
+		},
+		{
+			name:       "split",
+			gross:      1000,
+			components: []TaxComponent{cgst, sgst},
+			// 10 / 1.18 = 8.4745...
+			net:     "8.47",
+			tax:     "1.53",
+			amounts: []string{"CGST 0.77", "SGST 0.76"},
+		},
+		{
+			name:  "compound",
+			gross: 11498,
+			components: []TaxComponent{
+				{Name: "GST", Rate: MustParseRate("5%")},
+				{Name: "QST", Rate: MustParseRate("9.5%"), Compound: true},
+			},
+			net:     "100.00",
+			tax:     "14.98",
+			amounts: []string{"GST 5.00", "QST 9.98"},
+		},
+		{
+			name:       "zero",
+			gross:      0,
+			components: []TaxComponent{cgst, sgst},
+			net:        "0.00",
+			tax:        "0.00",
+			amounts:    []string{"CGST 0.00", "SGST 0.00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gross, err := NewFractional(tt.gross, "INR", "₹", "paise", 100)
+			require.NoError(t, err)
+
+			tb, err := gross.TaxInclusive(RoundHalfUp, tt.components...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.gross, tb.Gross.FractionalTotal())
+			assert.Equal(t, tt.net, tb.Net.StringWithoutSymbols())
+			assert.Equal(t, tt.tax, tb.Tax.StringWithoutSymbols())
+			assert.Equal(t, tt.amounts, taxAmounts(tb))
+		})
+	}
+
+	gross, err := New(100, 0, "INR", "₹", "paise", 100)
+	require.NoError(t, err)
+	_, err = gross.TaxInclusive(RoundHalfUp, TaxComponent{Rate: MustParseRate("-100%")})
+	assert.ErrorIs(t, err, ErrInvalidFactor)
+
+	_, err = (&Currency{}).TaxInclusive(RoundHalfUp, cgst)
+	assert.ErrorIs(t, err, ErrInvalidFUS)
+
+	_, err = (&Currency{}).TaxExclusive(RoundHalfUp, cgst)
+	assert.ErrorIs(t, err, ErrInvalidFUS)
+}
+
+func TestTaxInvariant(t *testing.T) {
+	components := []TaxComponent{
+		{Name: "A", Rate: MustParseRate("7.25%")},
+		{Name: "B", Rate: MustParseRate("1.5%"), Compound: true},
+		{Name: "C", Rate: MustParseRate("33bp")},
+	}
+
+	for ft := -2000; ft <= 2000; ft += 7 {
+		cur, err := NewFractional(ft, "USD", "$", "cent", 100)
+		require.NoError(t, err)
+
+		for _, mode := range []RoundingMode{RoundHalfUp, RoundHalfEven, RoundDown, RoundCeiling} {
+			for _, compute := range []func(RoundingMode, ...TaxComponent) (*TaxBreakdown, error){cur.TaxExclusive, cur.TaxInclusive} {
+				tb, err := compute(mode, components...)
+				require.NoError(t, err)
+				require.Equal(t, tb.Gross.FractionalTotal(), tb.Net.FractionalTotal()+tb.Tax.FractionalTotal(), ft)
+
+				sum := 0
+				for _, ta := range tb.Components {
+					sum += ta.Amount.FractionalTotal()
+				}
+				require.Equal(t, tb.Tax.FractionalTotal(), sum, ft)
+			}
+		}
+	}
+}
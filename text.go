@@ -0,0 +1,93 @@
+package currency
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// MarshalText implements encoding.TextMarshaler. The currency is represented as its code
+// followed by the amount, e.g. "USD 12.50". The zero value is represented as an empty text.
+func (c Currency) MarshalText() ([]byte, error) {
+	if c.isBlank() {
+		return []byte{}, nil
+	}
+
+	if c.FUShare == 0 {
+		return nil, ErrInvalidFUS
+	}
+
+	return []byte(c.Code + " " + c.decimalString()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The code and amount can be separated by
+// a space or a colon, e.g. "USD 12.50" or "USD:12.50". The currency symbol & digit grouping
+// in the amount are ignored, e.g. "USD:$1,250.75", and any other character is an error. If c
+// already has the meta data of the currency set, the code can be omitted. An empty text is a
+// no-op.
+func (c *Currency) UnmarshalText(text []byte) error {
+	str := strings.TrimSpace(string(text))
+	if str == "" {
+		return nil
+	}
+
+	code, amount := "", str
+	if idx := strings.IndexAny(str, ": "); idx >= 0 {
+		code, amount = str[:idx], str[idx+1:]
+	} else if c.FUShare != 0 {
+		code = c.Code
+	} else {
+		return fmt.Errorf("%w: %q, missing currency code", ErrInvalidCurrency, str)
+	}
+
+	m, err := c.metaFor(strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return fmt.Errorf("%w: %q", err, code)
+	}
+
+	return c.setDecimal(m, textAmount(amount, m.Symbol))
+}
+
+// groupSeparators is the replacer which removes the digit grouping separators of an amount
+var groupSeparators = strings.NewReplacer(",", "", "'", "", " ", "", "\u00a0", "", "\u202f", "")
+
+// textAmount returns the amount without the currency symbol & digit grouping separators. All
+// other characters are left as is, so that they're rejected while parsing.
+func textAmount(amount, symbol string) string {
+	if symbol != "" {
+		amount = strings.Replace(amount, symbol, "", 1)
+	}
+
+	return groupSeparators.Replace(strings.TrimSpace(amount))
+}
+
+// Flag returns a flag.Value for c, so that a currency can be accepted as a command-line flag. e.g.
+//
+//	maxRefund := currency.Currency{}
+//	flag.Var(currency.Flag(&maxRefund), "max-refund", "maximum refund amount, e.g. USD:250.00")
+func Flag(c *Currency) flag.Value {
+	return &flagValue{c: c}
+}
+
+// flagValue adapts a currency to flag.Value
+type flagValue struct {
+	c *Currency
+}
+
+func (fv *flagValue) String() string {
+	if fv.c == nil {
+		return ""
+	}
+
+	text, _ := fv.c.MarshalText()
+	return string(text)
+}
+
+func (fv *flagValue) Set(value string) error {
+	return fv.c.UnmarshalText([]byte(value))
+}
+
+// Type returns the type name of the flag, as required by pflag.Value
+func (fv *flagValue) Type() string {
+	return "currency"
+}
@@ -0,0 +1,120 @@
+package currency
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalText(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	usd, err := New(250, 0, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	text, err := usd.MarshalText()
+	requirer.NoError(err)
+	asserter.Equal("USD 250.00", string(text))
+
+	text, err = Currency{}.MarshalText()
+	requirer.NoError(err)
+	asserter.Equal("", string(text))
+
+	_, err = Currency{Code: "USD", Main: 1}.MarshalText()
+	asserter.ErrorIs(err, ErrInvalidFUS)
+}
+
+func TestUnmarshalText(t *testing.T) {
+	asserter := assert.New(t)
+
+	list := []struct {
+		Input  string
+		Target Currency
+		Str    string
+		Err    error
+	}{
+		{Input: "USD 12.50", Str: "12.50"},
+		{Input: "USD:250", Str: "250.00"},
+		{Input: "usd:-0.5", Str: "-0.50"},
+		{Input: "USD:$1,250.75", Str: "1250.75"},
+		{Input: "JPY 1200", Str: "1200"},
+		{Input: "KWD 0.125", Str: "0.125"},
+		{Input: "12.50", Target: Currency{Code: "INR", Symbol: "₹", FUShare: 100}, Str: "12.50"},
+		{Input: "", Str: "0"},
+		{Input: "12.50", Err: ErrInvalidCurrency},
+		{Input: "USD 12.505", Err: ErrPrecisionLoss},
+		{Input: "XYZ 1", Err: ErrUnknownCurrency},
+		{Input: "USD abc", Err: ErrInvalidCurrency},
+		{Input: "USD 1e3", Err: ErrInvalidCurrency},
+		{Input: "USD 12.5O", Err: ErrInvalidCurrency},
+		{Input: "USD €12.50", Err: ErrInvalidCurrency},
+		{Input: "INR ₹1,00,000", Str: "100000.00"},
+		{Input: "CHF 1'250.50", Str: "1250.50"},
+	}
+
+	for _, l := range list {
+		cur := l.Target
+		err := cur.UnmarshalText([]byte(l.Input))
+		if l.Err != nil {
+			asserter.ErrorIs(err, l.Err, l.Input)
+			continue
+		}
+
+		if asserter.NoError(err, l.Input) {
+			asserter.Equal(l.Str, cur.decimalString(), l.Input)
+		}
+	}
+}
+
+func TestTextEncoders(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	type refund struct {
+		XMLName xml.Name `xml:"refund"`
+		Max     Currency `xml:"max,attr"`
+	}
+
+	usd, err := New(250, 0, "USD", "$", "cent", 100)
+	requirer.NoError(err)
+
+	out, err := xml.Marshal(refund{Max: *usd})
+	requirer.NoError(err)
+	asserter.Equal(`<refund max="USD 250.00"></refund>`, string(out))
+
+	decoded := refund{}
+	requirer.NoError(xml.Unmarshal(out, &decoded))
+	asserter.Equal(25000, decoded.Max.FractionalTotal())
+	asserter.Equal("USD", decoded.Max.Code)
+
+	limits := map[string]Currency{}
+	requirer.NoError(json.Unmarshal([]byte(`{"daily":"EUR 100.00"}`), &limits))
+	daily := limits["daily"]
+	asserter.Equal("100.00", daily.StringWithoutSymbols())
+}
+
+func TestFlag(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	maxRefund := Currency{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(Flag(&maxRefund), "max-refund", "maximum refund amount")
+
+	requirer.NoError(fs.Parse([]string{"--max-refund=USD:250.00"}))
+	asserter.Equal(25000, maxRefund.FractionalTotal())
+	asserter.Equal("USD", maxRefund.Code)
+	asserter.Equal("USD 250.00", fs.Lookup("max-refund").Value.String())
+
+	asserter.Error(fs.Parse([]string{"--max-refund=USD:1.001"}))
+
+	fv := Flag(&maxRefund).(interface{ Type() string })
+	asserter.Equal("currency", fv.Type())
+}
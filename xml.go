@@ -0,0 +1,99 @@
+package currency
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrISO20022Amount is the error returned when an amount violates the ISO 20022 constraints of
+// ActiveOrHistoricCurrencyAndAmount
+var ErrISO20022Amount = errors.New("amount violates ISO 20022 constraints")
+
+const (
+	// iso20022TotalDigits is the maximum number of digits allowed in an ISO 20022 amount
+	iso20022TotalDigits = 18
+	// iso20022FractionDigits is the maximum number of fraction digits allowed in an ISO 20022 amount
+	iso20022FractionDigits = 5
+	// iso20022CcyAttr is the name of the attribute holding the currency code
+	iso20022CcyAttr = "Ccy"
+)
+
+// MarshalXML implements xml.Marshaler, producing an ISO 20022 amount element. The element name
+// is taken from the struct field, e.g. <InstdAmt Ccy="EUR">1234.56</InstdAmt>. The amount has
+// exactly as many decimal places as the fractional unit of the currency.
+func (c Currency) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if c.FUShare == 0 {
+		return ErrInvalidFUS
+	}
+
+	amount := c.decimalString()
+	err := validateISO20022(amount)
+	if err != nil {
+		return err
+	}
+
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: iso20022CcyAttr}, Value: c.Code})
+	return e.EncodeElement(amount, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler, consuming an ISO 20022 amount element. The meta data
+// of the currency is taken from c if it already has the same code, otherwise from the registry.
+func (c *Currency) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	code := ""
+	for _, attr := range start.Attr {
+		if attr.Name.Local == iso20022CcyAttr {
+			code = attr.Value
+		}
+	}
+
+	amount := ""
+	err := d.DecodeElement(&amount, &start)
+	if err != nil {
+		return err
+	}
+
+	if !validCode(code) {
+		return fmt.Errorf("%w: %q", ErrInvalidCode, code)
+	}
+
+	amount = strings.TrimSpace(amount)
+	err = validateISO20022(amount)
+	if err != nil {
+		return err
+	}
+
+	m, err := c.metaFor(code)
+	if err != nil {
+		return fmt.Errorf("%w: %q", err, code)
+	}
+
+	return c.setDecimal(m, amount)
+}
+
+// validateISO20022 checks the decimal string amount against the ISO 20022 constraints, i.e.
+// non-negative, at most 18 digits in total, and at most 5 fraction digits.
+func validateISO20022(amount string) error {
+	if strings.HasPrefix(amount, "-") {
+		return fmt.Errorf("%w: %q is negative", ErrISO20022Amount, amount)
+	}
+
+	intpart, fracpart := amount, ""
+	if idx := strings.IndexByte(amount, '.'); idx >= 0 {
+		intpart, fracpart = amount[:idx], amount[idx+1:]
+	}
+
+	intpart = strings.TrimLeft(strings.TrimPrefix(intpart, "+"), "0")
+	fracpart = strings.TrimRight(fracpart, "0")
+
+	if len(fracpart) > iso20022FractionDigits {
+		return fmt.Errorf("%w: %q has more than %d fraction digits", ErrISO20022Amount, amount, iso20022FractionDigits)
+	}
+
+	if len(intpart)+len(fracpart) > iso20022TotalDigits {
+		return fmt.Errorf("%w: %q has more than %d digits", ErrISO20022Amount, amount, iso20022TotalDigits)
+	}
+
+	return nil
+}
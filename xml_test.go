@@ -0,0 +1,92 @@
+package currency
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type creditTransfer struct {
+	XMLName  xml.Name `xml:"CdtTrfTxInf"`
+	InstdAmt Currency `xml:"Amt>InstdAmt"`
+}
+
+func TestMarshalXML(t *testing.T) {
+	requirer := require.New(t)
+	asserter := assert.New(t)
+
+	list := []struct {
+		Code     string
+		FUShare  uint
+		Total    int
+		Expected string
+		Err      error
+	}{
+		{Code: "EUR", FUShare: 100, Total: 123456, Expected: `<CdtTrfTxInf><Amt><InstdAmt Ccy="EUR">1234.56</InstdAmt></Amt></CdtTrfTxInf>`},
+		{Code: "EUR", FUShare: 100, Total: 5, Expected: `<CdtTrfTxInf><Amt><InstdAmt Ccy="EUR">0.05</InstdAmt></Amt></CdtTrfTxInf>`},
+		{Code: "JPY", FUShare: 1, Total: 1234, Expected: `<CdtTrfTxInf><Amt><InstdAmt Ccy="JPY">1234</InstdAmt></Amt></CdtTrfTxInf>`},
+		{Code: "KWD", FUShare: 1000, Total: 1500, Expected: `<CdtTrfTxInf><Amt><InstdAmt Ccy="KWD">1.500</InstdAmt></Amt></CdtTrfTxInf>`},
+		{Code: "EUR", FUShare: 100, Total: -100, Err: ErrISO20022Amount},
+		{Code: "EUR", FUShare: 100, Total: 1234567890123456789, Err: ErrISO20022Amount},
+	}
+
+	for _, l := range list {
+		cur, err := NewFractional(l.Total, l.Code, "", "", l.FUShare)
+		requirer.NoError(err)
+
+		out, err := xml.Marshal(creditTransfer{InstdAmt: *cur})
+		if l.Err != nil {
+			asserter.ErrorIs(err, l.Err, l.Expected)
+			continue
+		}
+
+		requirer.NoError(err)
+		asserter.Equal(l.Expected, string(out))
+
+		decoded := creditTransfer{}
+		requirer.NoError(xml.Unmarshal(out, &decoded))
+		asserter.Equal(l.Code, decoded.InstdAmt.Code)
+		asserter.Equal(l.Total, decoded.InstdAmt.FractionalTotal())
+	}
+
+	_, err := xml.Marshal(creditTransfer{})
+	asserter.ErrorIs(err, ErrInvalidFUS)
+}
+
+func TestUnmarshalXML(t *testing.T) {
+	asserter := assert.New(t)
+
+	list := []struct {
+		Input string
+		Total int
+		Err   error
+	}{
+		{Input: `<InstdAmt Ccy="EUR">1234.56</InstdAmt>`, Total: 123456},
+		{Input: `<InstdAmt Ccy="EUR"> 10.5 </InstdAmt>`, Total: 1050},
+		{Input: `<InstdAmt Ccy="EUR">10.50000</InstdAmt>`, Total: 1050},
+		{Input: `<InstdAmt Ccy="EUR">10.123</InstdAmt>`, Err: ErrPrecisionLoss},
+		{Input: `<InstdAmt Ccy="EUR">10.123456</InstdAmt>`, Err: ErrISO20022Amount},
+		{Input: `<InstdAmt Ccy="EUR">1234567890123456789</InstdAmt>`, Err: ErrISO20022Amount},
+		{Input: `<InstdAmt Ccy="EUR">-1.00</InstdAmt>`, Err: ErrISO20022Amount},
+		{Input: `<InstdAmt>1.00</InstdAmt>`, Err: ErrInvalidCode},
+		{Input: `<InstdAmt Ccy="eur">1.00</InstdAmt>`, Err: ErrInvalidCode},
+		{Input: `<InstdAmt Ccy="XYZ">1.00</InstdAmt>`, Err: ErrUnknownCurrency},
+		{Input: `<InstdAmt Ccy="EUR">abc</InstdAmt>`, Err: ErrInvalidCurrency},
+	}
+
+	for _, l := range list {
+		cur := Currency{}
+		err := xml.Unmarshal([]byte(l.Input), &cur)
+		if l.Err != nil {
+			asserter.ErrorIs(err, l.Err, l.Input)
+			continue
+		}
+
+		if asserter.NoError(err, l.Input) {
+			asserter.Equal(l.Total, cur.FractionalTotal(), l.Input)
+			asserter.Equal("€", cur.Symbol, l.Input)
+		}
+	}
+}